@@ -0,0 +1,122 @@
+package kamera
+
+import "math"
+
+// AutoScrollOptions holds the auto-scroll mode settings for shoot-em-ups and runners.
+//
+// The camera scrolls on its own from the camera center at the time the mode is enabled
+// (or at the last SetCenter()) and the LookAt() target is ignored.
+type AutoScrollOptions struct {
+	// DirectionX and DirectionY are the scroll direction. It is normalized. Default is (1, 0)
+	DirectionX, DirectionY float64
+	// Speed is the scroll speed in world units per second. Default is 60
+	Speed float64
+	// SpeedOverTime returns the scroll speed for the scrolling time in seconds. It is optional.
+	//
+	// If it is not nil, Speed is ignored.
+	SpeedOverTime func(seconds float64) float64
+	// SpeedOverDistance returns the scroll speed for the scrolled distance. It is optional.
+	//
+	// If it is not nil, Speed and SpeedOverTime are ignored.
+	SpeedOverDistance func(distance float64) float64
+	// If Paused is true, the camera does not scroll and the scrolling time does not advance.
+	Paused bool
+}
+
+// autoScrollState is the scroll position of the auto-scroll mode.
+type autoScrollState struct {
+	init             bool
+	originX, originY float64
+	elapsed          float64
+	distance         float64
+}
+
+// DefaultAutoScrollOptions returns the default auto-scroll options.
+func DefaultAutoScrollOptions() *AutoScrollOptions {
+	return &AutoScrollOptions{
+		DirectionX: 1,
+		Speed:      60,
+	}
+}
+
+// speed returns the scroll speed for the scroll state.
+func (o *AutoScrollOptions) speed(s *autoScrollState) float64 {
+	switch {
+	case o.SpeedOverDistance != nil:
+		return o.SpeedOverDistance(s.distance)
+	case o.SpeedOverTime != nil:
+		return o.SpeedOverTime(s.elapsed)
+	default:
+		return o.Speed
+	}
+}
+
+// AutoScrollTime returns the scrolling time in seconds, without the paused time.
+func (cam *Camera) AutoScrollTime() float64 {
+	return cam.autoScroll.elapsed
+}
+
+// AutoScrollDistance returns the scrolled distance in world units.
+func (cam *Camera) AutoScrollDistance() float64 {
+	return cam.autoScroll.distance
+}
+
+// ResetAutoScroll restarts the auto-scroll from the current camera center.
+func (cam *Camera) ResetAutoScroll() {
+	cam.autoScroll = autoScrollState{}
+}
+
+// autoScrollTarget advances the auto-scroll by one frame and returns the camera center.
+func (cam *Camera) autoScrollTarget() (float64, float64) {
+	o := cam.AutoScroll
+	s := &cam.autoScroll
+	if !s.init {
+		*s = autoScrollState{init: true, originX: cam.TempTargetX, originY: cam.TempTargetY}
+	}
+	if !o.Paused {
+		s.distance += o.speed(s) * cam.dt
+		s.elapsed += cam.dt
+	}
+	dirX, dirY := o.DirectionX, o.DirectionY
+	if length := math.Hypot(dirX, dirY); length > 0 {
+		dirX, dirY = dirX/length, dirY/length
+	}
+	return s.originX + dirX*s.distance, s.originY + dirY*s.distance
+}
+
+// ViewRect returns the world-space rectangle visible to the camera, accounting for zoom.
+//
+// The rectangle is placed at the camera center without shake, using the framing anchor and the pivot. Rotation is ignored.
+func (cam *Camera) ViewRect() Rect {
+	zoom := cam.ZoomFactor
+	if zoom <= 0 {
+		zoom = 1
+	}
+	x, y := cam.worldAt(cam.TempTargetX, cam.TempTargetY, 0, 0)
+	return Rect{x, y, cam.Width / zoom, cam.Height / zoom}
+}
+
+// IsInView returns true if the point is inside the ViewRect().
+func (cam *Camera) IsInView(x, y float64) bool {
+	return cam.ViewRect().Contains(x, y)
+}
+
+// ClampToView returns the rectangle moved into the ViewRect() and the push distance.
+//
+// Use it to push the player with the screen edge. If the player is pushed into a wall and the collision
+// response moves it back out of the view, it is crushed (see IsCrushed()).
+func (cam *Camera) ClampToView(r Rect) (clamped Rect, pushX, pushY float64) {
+	view := cam.ViewRect()
+	x, y := r.X, r.Y
+	r.X = clampAxis(r.X+r.Width*0.5, r.Width*0.5, view.X, view.Right()) - r.Width*0.5
+	r.Y = clampAxis(r.Y+r.Height*0.5, r.Height*0.5, view.Y, view.Bottom()) - r.Height*0.5
+	return r, r.X - x, r.Y - y
+}
+
+// IsCrushed returns true if the rectangle is not completely inside the ViewRect().
+//
+// Call it after the collision response of a rectangle clamped with ClampToView().
+func (cam *Camera) IsCrushed(r Rect) bool {
+	view := cam.ViewRect()
+	return r.X < view.X || r.Y < view.Y || r.Right() > view.Right() || r.Bottom() > view.Bottom()
+}
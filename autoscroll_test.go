@@ -0,0 +1,56 @@
+package kamera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestAutoScroll(t *testing.T) {
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	cam.AutoScroll = kamera.DefaultAutoScrollOptions()
+	for range 60 {
+		cam.LookAt(-500, 300)
+	}
+	if math.Abs(cam.CenterX()-60) > 1e-9 || cam.CenterY() != 0 {
+		t.Error(cam.CenterX(), cam.CenterY())
+	}
+	cam.AutoScroll.Paused = true
+	cam.LookAt(0, 0)
+	if math.Abs(cam.CenterX()-60) > 1e-9 || math.Abs(cam.AutoScrollTime()-1) > 1e-9 {
+		t.Error(cam.CenterX(), cam.AutoScrollTime())
+	}
+	cam.AutoScroll.Paused = false
+	cam.AutoScroll.SpeedOverDistance = func(d float64) float64 {
+		if d >= 60 {
+			return 120
+		}
+		return 60
+	}
+	for range 30 {
+		cam.LookAt(0, 0)
+	}
+	if math.Abs(cam.AutoScrollDistance()-120) > 1e-9 {
+		t.Error(cam.AutoScrollDistance())
+	}
+}
+
+func TestClampToView(t *testing.T) {
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	cam.ZoomFactor = 2
+	cam.LookAt(0, 0)
+	if v := cam.ViewRect(); v != (kamera.Rect{X: -25, Y: -25, Width: 50, Height: 50}) {
+		t.Error(v)
+	}
+	r, pushX, pushY := cam.ClampToView(kamera.Rect{X: -30, Y: 0, Width: 10, Height: 10})
+	if r.X != -25 || pushX != 5 || pushY != 0 {
+		t.Error(r, pushX, pushY)
+	}
+	if cam.IsCrushed(r) || !cam.IsCrushed(kamera.Rect{X: -30, Y: 0, Width: 10, Height: 10}) {
+		t.Error()
+	}
+	if !cam.IsInView(0, 0) || cam.IsInView(30, 0) {
+		t.Error()
+	}
+}
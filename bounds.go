@@ -0,0 +1,43 @@
+package kamera
+
+// clampToBounds returns the camera center clamped so the view stays inside the bounds.
+func (cam *Camera) clampToBounds(x, y float64, bounds Rect) (float64, float64) {
+	if bounds.Empty() {
+		return x, y
+	}
+	// the camera centers that place the bounds edges at the view edges
+	loX, loY := cam.centerAt(bounds.X, bounds.Y, 0, 0)
+	hiX, hiY := cam.centerAt(bounds.Right(), bounds.Bottom(), cam.Width, cam.Height)
+	return clampCenter(x, loX, hiX), clampCenter(y, loY, hiY)
+}
+
+// clampCenter clamps the camera center into [lo, hi]. If the view is larger than the bounds (lo > hi), it is centered.
+func clampCenter(pos, lo, hi float64) float64 {
+	if lo > hi {
+		return (lo + hi) * 0.5
+	}
+	return min(max(pos, lo), hi)
+}
+
+// applyBounds clamps the smoothed camera center to the active bounds, so the smoothing does not overshoot them.
+//
+// The bounds of the active zone are used once it is fully blended in, before that the camera Bounds are used.
+func (cam *Camera) applyBounds() {
+	bounds := cam.Bounds
+	if z := cam.activeZone; z != nil && !z.Bounds.Empty() {
+		for _, s := range cam.zoneStates {
+			if s.zone == z && s.progress >= 1 {
+				bounds = z.Bounds
+			}
+		}
+	}
+	x, y := cam.clampToBounds(cam.TempTargetX, cam.TempTargetY, bounds)
+	// the smoothing velocity is stopped on the clamped axis, as in applyHardLimit()
+	if x != cam.TempTargetX {
+		cam.CurrentVelocityX = 0
+	}
+	if y != cam.TempTargetY {
+		cam.CurrentVelocityY = 0
+	}
+	cam.TempTargetX, cam.TempTargetY = x, y
+}
@@ -0,0 +1,42 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestBounds(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	// no limit by default
+	k.LookAt(-500, 300)
+	if x, y := k.Center(); x != -500 || y != 300 {
+		t.Error(x, y)
+	}
+	k.Bounds = kamera.Rect{X: 0, Y: 0, Width: 1000, Height: 80}
+	k.LookAt(-500, 300)
+	x, y := k.Center()
+	if x != 50 || y != 40 {
+		t.Error(x, y)
+	}
+	k.ZoomFactor = 2
+	k.LookAt(990, 0)
+	if x := k.CenterX(); x != 975 {
+		t.Error(x)
+	}
+}
+
+func TestBoundsSpringOvershoot(t *testing.T) {
+	k := kamera.NewCamera(50, 50, 100, 100)
+	k.SmoothType = kamera.Spring
+	k.SmoothOptions.SpringDampingX = 0.2
+	k.Bounds = kamera.Rect{X: 0, Y: 0, Width: 200, Height: 200}
+	maxX := 0.0
+	for range 120 {
+		k.LookAt(500, 50)
+		maxX = max(maxX, k.CenterX())
+	}
+	if maxX > 150 || k.CenterX() != 150 || k.CurrentVelocityX != 0 {
+		t.Error(maxX, k.CenterX(), k.CurrentVelocityX)
+	}
+}
@@ -20,11 +20,61 @@ const (
 	Lerp
 	// SmoothDamp is SmoothDamp() function.
 	SmoothDamp
+	// Spring is a second-order dynamics system with frequency, damping ratio and initial response.
+	//
+	// See SmoothOptions.SpringFrequencyX.
+	Spring
+)
+
+// PivotMode is the zoom and rotation pivot mode.
+type PivotMode int
+
+const (
+	// PivotAnchor rotates and scales around the framing anchor (see Camera.AnchorX).
+	PivotAnchor PivotMode = iota
+	// PivotScreen rotates and scales around (Camera.PivotX, Camera.PivotY) in screen fractions.
+	PivotScreen
+	// PivotWorld rotates and scales around (Camera.PivotX, Camera.PivotY) in world-space.
+	PivotWorld
+)
+
+// ShakeChannel is a bit set of camera shake channels.
+type ShakeChannel int
+
+const (
+	// ShakeX is the X-axis translation channel.
+	ShakeX ShakeChannel = 1 << iota
+	// ShakeY is the Y-axis translation channel.
+	ShakeY
+	// ShakeAngle is the rotation channel.
+	ShakeAngle
+	// ShakeZoom is the zoom channel.
+	ShakeZoom
+	// ShakeTranslation is the X and Y translation channels.
+	ShakeTranslation = ShakeX | ShakeY
+	// ShakeAll is all channels.
+	ShakeAll = ShakeX | ShakeY | ShakeAngle | ShakeZoom
+)
+
+// DecayType is the trauma decay type.
+type DecayType int
+
+const (
+	// LinearDecay decreases trauma by ShakeOptions.Decay per second.
+	LinearDecay DecayType = iota
+	// ExponentialDecay multiplies trauma by exp(-ShakeOptions.Decay) per second.
+	ExponentialDecay
 )
 
+// DeltaTime is the time step of one LookAt() call in seconds.
+const DeltaTime = deltaTime
+
 const (
 	deltaTime     float64 = 1.0 / 60.0
 	noise3DOffset float64 = 300.0
+	traumaEpsilon float64 = 0.001
+	// tickWrapFrames is the shake frame count at which Tick wraps to zero.
+	tickWrapFrames uint64 = 60000000
 )
 
 // Camera object.
@@ -49,10 +99,38 @@ type Camera struct {
 	ActualAngle float64
 	// ZoomFactor is the camera zoom (scaling) factor. Default is 1.
 	ZoomFactor float64
+	// AnchorX and AnchorY are the screen-space position of the LookAt() target in screen fractions.
+	//
+	// Default is 0.5 (viewport center). For example, AnchorX = 1.0/3 places the target one-third from the left.
+	// Zoom and rotation pivot around the anchor. A zero Camera uses the viewport center until the anchor
+	// is set with SetAnchor() or to a non-zero value, so use SetAnchor(0, 0) for a top-left anchor.
+	AnchorX, AnchorY float64
+	// AnchorLerpSpeed is the Lerp speed [0-1] of anchor changes per 1/60 second. 0 means the anchor changes instantly.
+	AnchorLerpSpeed float64
+	// PivotMode is the zoom and rotation pivot mode. Default is PivotAnchor
+	PivotMode PivotMode
+	// PivotX and PivotY are the zoom and rotation pivot. Used only if PivotMode is not PivotAnchor.
+	//
+	// The unit is screen fractions for PivotScreen and world units for PivotWorld.
+	PivotX, PivotY float64
 	// SmoothType is the camera movement smoothing type.
 	SmoothType SmoothType
+	// If PerAxisSmoothType is true, SmoothTypeX and SmoothTypeY are used instead of SmoothType.
+	//
+	// The default value is false
+	PerAxisSmoothType bool
+	// SmoothTypeX is the X-axis smoothing type. Used only if PerAxisSmoothType is true.
+	SmoothTypeX SmoothType
+	// SmoothTypeY is the Y-axis smoothing type. Used only if PerAxisSmoothType is true.
+	SmoothTypeY SmoothType
 	// Trauma factor. Factor is in the range [0-1]. Use AddTrauma() function
+	//
+	// Trauma shakes all channels.
 	Trauma float64
+	// Per-channel trauma factors in the range [0-1]. Use AddChannelTrauma() function
+	//
+	// The shake of a channel is driven by Trauma plus the channel trauma.
+	TraumaX, TraumaY, TraumaAngle, TraumaZoom float64
 	// SmoothOptions holds the camera movement smoothing settings
 	SmoothOptions *SmoothOptions
 	// ShakeOptions holds the camera shake options.
@@ -65,12 +143,88 @@ type Camera struct {
 	XAxisSmoothingDisabled bool
 	// YAxisSmoothingDisabled disables the smoothing of the Y axis if it's true.
 	YAxisSmoothingDisabled bool
+	// DeadZoneX is the half-width of the dead zone around the camera center in world units.
+	//
+	// The camera does not follow the target while it is inside the dead zone. 0 means disabled.
+	DeadZoneX float64
+	// DeadZoneY is the half-height of the dead zone around the camera center in world units.
+	//
+	// The camera does not follow the target while it is inside the dead zone. 0 means disabled.
+	DeadZoneY float64
+	// MinZoom is the minimum ZoomFactor applied by LookAt(). 0 means no limit.
+	MinZoom float64
+	// MaxZoom is the maximum ZoomFactor applied by LookAt(). 0 means no limit.
+	MaxZoom float64
+	// If GroundLock is true, LookAtGrounded() re-targets the Y axis only on landing
+	// or when the target leaves the vertical band. The default value is false
+	GroundLock bool
+	// GroundLockBandUp is the distance above the ground height the target can jump
+	// before the camera follows it vertically. 0 means no limit.
+	GroundLockBandUp float64
+	// GroundLockBandDown is the distance below the ground height the target can fall
+	// before the camera follows it vertically. 0 means no limit.
+	GroundLockBandDown float64
+	// HardLimit is the screen-space rectangle the target can never leave, in screen fractions [0-1].
+	//
+	// For example, Rect{X: 0.3, Y: 0.2, Width: 0.4, Height: 0.6} keeps the target between 30% and 70%
+	// horizontally and between 20% and 80% vertically, regardless of the smoothing settings.
+	// Between the dead zone and the hard limit (the soft zone), the camera follows with smoothing.
+	// Bounds have priority over the hard limit. Empty rectangle means disabled.
+	HardLimit Rect
+	// Bounds limits the camera view to a world-space rectangle in LookAt().
+	//
+	// The view is centered in the bounds if it is larger. Empty rectangle means no limit. Default is empty
+	Bounds Rect
+	// Rail constrains the camera center to a path if it is not nil. Default is nil.
+	Rail *Rail
+	// FlipScreen enables the flip-screen (screen-by-screen) mode if it is not nil. Default is nil.
+	FlipScreen *FlipScreenOptions
+	// AutoScroll enables the auto-scroll mode if it is not nil. The LookAt() target is ignored. Default is nil.
+	AutoScroll *AutoScrollOptions
+	// Zones are the camera zones evaluated in LookAt(). See Zone.
+	Zones []*Zone
+	// Recorder records every LookAt() call if it is not nil. Default is nil.
+	Recorder *TraceRecorder
 	// Internal camera values. Do not change directly.
 	Tick, ZoomFactorShake float64
+	// Internal camera values. The last target passed to LookAt(). Do not change directly.
+	InputTargetX, InputTargetY float64
+	// ShakeFrame is the frame index of the shake noise. Tick is derived from it and,
+	// with time steps other than 1/60 second, the fraction of the next frame.
+	//
+	// Internal camera value. Use SetShakeFrame() to change it.
+	ShakeFrame uint64
 	// Internal camera values. Do not change directly.
-	TempTargetX, CenterOffsetX, TraumaOffsetX, CurrentVelocityX float64
+	TempTargetX, TraumaOffsetX, CurrentVelocityX float64
 	// Internal camera values. Do not change directly.
-	TempTargetY, CenterOffsetY, TraumaOffsetY, CurrentVelocityY float64
+	TempTargetY, TraumaOffsetY, CurrentVelocityY float64
+	// Internal camera values. The center offset is derived from the size and the current anchor
+	// (-Width*CurrentAnchorX, -Height*CurrentAnchorY) in LookAt(), SetSize() and Restore(). Do not change directly.
+	CenterOffsetX, CenterOffsetY float64
+	// Internal camera values. The previous smoothing target. Do not change directly.
+	PrevTargetX, PrevTargetY float64
+	// Internal camera value. The last ground height of LookAtGrounded(). Do not change directly.
+	GroundLockY float64
+	// Internal camera values. The current (smoothed) framing anchor. Do not change directly.
+	CurrentAnchorX, CurrentAnchorY float64
+
+	presetBlend  *presetBlend
+	activeZone   *Zone
+	zoneStates   []zoneState
+	zoneBaseZoom float64
+	flipScreen   flipScreenState
+	autoScroll   autoScrollState
+	// zoneZoomScale is the ZoomFactor change made while a zone zoom is applied.
+	zoneZoomScale float64
+	// lastZoom is the ZoomFactor after the last LookAt() call.
+	lastZoom float64
+	// viewPivot is true if the screen pivot is set by a Brain until the next LookAt() call.
+	viewPivot              bool
+	viewPivotX, viewPivotY float64
+	// dt is the time step of the current LookAtDt() call.
+	dt float64
+	// anchorSet is true if AnchorX and AnchorY are used even if both are 0.
+	anchorSet bool
 }
 
 // NewCamera returns new Camera
@@ -85,26 +239,36 @@ func NewCamera(lookAtX, lookAtY, w, h float64) *Camera {
 		Angle:           0,
 		ZoomFactorShake: 1.0,
 		Trauma:          0,
+		AnchorX:         0.5,
+		AnchorY:         0.5,
+		CurrentAnchorX:  0.5,
+		CurrentAnchorY:  0.5,
 		CenterOffsetX:   -(w * 0.5),
 		CenterOffsetY:   -(h * 0.5),
 		Tick:            0,
+		anchorSet:       true,
 	}
 
 	c.LookAt(lookAtX, lookAtY)
 	c.TempTargetX = lookAtX
 	c.TempTargetY = lookAtY
+	c.PrevTargetX = lookAtX
+	c.PrevTargetY = lookAtY
+	c.GroundLockY = lookAtY
 	return c
 }
 
 func DefaultCameraShakeOptions() *ShakeOptions {
 	opt := &ShakeOptions{
-		Noise:         fastnoise.NewNoiseState[float64](),
-		MaxX:          10.0,
-		MaxY:          10.0,
-		MaxAngle:      0.05,
-		MaxZoomFactor: 0.1,
-		Decay:         0.666,
-		TimeScale:     10,
+		Noise:          fastnoise.NewNoiseState[float64](),
+		MaxX:           10.0,
+		MaxY:           10.0,
+		MaxAngle:       0.05,
+		MaxZoomFactor:  0.1,
+		Decay:          0.666,
+		TimeScale:      10,
+		TraumaExponent: 2,
+		DecayType:      LinearDecay,
 	}
 	opt.Noise.Frequency = 0.5
 	return opt
@@ -113,17 +277,18 @@ func DefaultCameraShakeOptions() *ShakeOptions {
 // smoothDampX gradually changes a value towards a desired goal over time for X axis.
 func (cam *Camera) smoothDampX(targetX float64) float64 {
 	// Ensure smooth time is not too small to avoid division by zero
-	smoothTimeX := math.Max(0.0001, cam.SmoothOptions.SmoothDampTimeX)
+	dirX := targetX - cam.TempTargetX
+	smoothTimeX := math.Max(0.0001, directional(cam.SmoothOptions.SmoothDampTimeX, cam.SmoothOptions.SmoothDampTimeNegX, dirX))
 
 	// Calculate exponential decay factor for X
 	omegaX := 2.0 / smoothTimeX
-	xX := omegaX * deltaTime
+	xX := omegaX * cam.dt
 	expX := 1.0 / (1.0 + xX + 0.48*xX*xX + 0.235*xX*xX*xX)
 
 	// Calculate change with max speed
 	changeX := cam.TempTargetX - targetX
 	originalToX := targetX
-	maxChangeX := cam.SmoothOptions.SmoothDampMaxSpeedX * smoothTimeX
+	maxChangeX := directional(cam.SmoothOptions.SmoothDampMaxSpeedX, cam.SmoothOptions.SmoothDampMaxSpeedNegX, dirX) * smoothTimeX
 	maxChangeXSq := maxChangeX * maxChangeX
 
 	// Limit change
@@ -134,7 +299,7 @@ func (cam *Camera) smoothDampX(targetX float64) float64 {
 	targetX = cam.TempTargetX - changeX
 
 	// Calculate velocity and output with exponential decay
-	tempVelocityX := (cam.CurrentVelocityX + changeX*omegaX) * deltaTime
+	tempVelocityX := (cam.CurrentVelocityX + changeX*omegaX) * cam.dt
 	cam.CurrentVelocityX = (cam.CurrentVelocityX - tempVelocityX*omegaX) * expX
 	outputX := targetX + (changeX+tempVelocityX)*expX
 
@@ -144,7 +309,7 @@ func (cam *Camera) smoothDampX(targetX float64) float64 {
 
 	if origMinusCurrentX*outMinusOrigX > 0 {
 		outputX = originalToX
-		cam.CurrentVelocityX = (outputX - originalToX) / deltaTime
+		cam.CurrentVelocityX = (outputX - originalToX) / cam.dt
 	}
 
 	return outputX
@@ -153,17 +318,18 @@ func (cam *Camera) smoothDampX(targetX float64) float64 {
 // smoothDampY gradually changes a value towards a desired goal over time for Y axis.
 func (cam *Camera) smoothDampY(targetY float64) float64 {
 	// Ensure smooth time is not too small to avoid division by zero
-	smoothTimeY := math.Max(0.0001, cam.SmoothOptions.SmoothDampTimeY)
+	dirY := targetY - cam.TempTargetY
+	smoothTimeY := math.Max(0.0001, directional(cam.SmoothOptions.SmoothDampTimeY, cam.SmoothOptions.SmoothDampTimeNegY, dirY))
 
 	// Calculate exponential decay factor for Y
 	omegaY := 2.0 / smoothTimeY
-	xY := omegaY * 0.016666666666666666
+	xY := omegaY * cam.dt
 	expY := 1.0 / (1.0 + xY + 0.48*xY*xY + 0.235*xY*xY*xY)
 
 	// Calculate change with max speed
 	changeY := cam.TempTargetY - targetY
 	originalToY := targetY
-	maxChangeY := cam.SmoothOptions.SmoothDampMaxSpeedY * smoothTimeY
+	maxChangeY := directional(cam.SmoothOptions.SmoothDampMaxSpeedY, cam.SmoothOptions.SmoothDampMaxSpeedNegY, dirY) * smoothTimeY
 	maxChangeYSq := maxChangeY * maxChangeY
 
 	// Limit change
@@ -174,7 +340,7 @@ func (cam *Camera) smoothDampY(targetY float64) float64 {
 	targetY = cam.TempTargetY - changeY
 
 	// Calculate velocity and output with exponential decay
-	tempVelocityY := (cam.CurrentVelocityY + changeY*omegaY) * deltaTime
+	tempVelocityY := (cam.CurrentVelocityY + changeY*omegaY) * cam.dt
 	cam.CurrentVelocityY = (cam.CurrentVelocityY - tempVelocityY*omegaY) * expY
 	outputY := targetY + (changeY+tempVelocityY)*expY
 
@@ -184,81 +350,250 @@ func (cam *Camera) smoothDampY(targetY float64) float64 {
 
 	if origMinusCurrentY*outMinusOrigY > 0 {
 		outputY = originalToY
-		cam.CurrentVelocityY = (outputY - originalToY) / deltaTime
+		cam.CurrentVelocityY = (outputY - originalToY) / cam.dt
 	}
 
 	return outputY
 }
 
+// smoothDampRadial gradually changes the position towards a desired goal over time along the displacement vector.
+//
+// Unlike smoothDampX and smoothDampY, the total speed is clamped.
+func (cam *Camera) smoothDampRadial(targetX, targetY float64) (float64, float64) {
+	o := cam.SmoothOptions
+	smoothTime, maxSpeed := o.SmoothDampTimeX, o.SmoothDampMaxSpeedX
+	// The dominant axis of the displacement picks the negative direction options
+	if dx, dy := targetX-cam.TempTargetX, targetY-cam.TempTargetY; math.Abs(dx) >= math.Abs(dy) {
+		smoothTime = directional(smoothTime, o.SmoothDampTimeNegX, dx)
+		maxSpeed = directional(maxSpeed, o.SmoothDampMaxSpeedNegX, dx)
+	} else {
+		smoothTime = directional(smoothTime, o.SmoothDampTimeNegY, dy)
+		maxSpeed = directional(maxSpeed, o.SmoothDampMaxSpeedNegY, dy)
+	}
+
+	// Ensure smooth time is not too small to avoid division by zero
+	smoothTime = math.Max(0.0001, smoothTime)
+
+	// Calculate exponential decay factor
+	omega := 2.0 / smoothTime
+	x := omega * cam.dt
+	exp := 1.0 / (1.0 + x + 0.48*x*x + 0.235*x*x*x)
+
+	// Calculate change with max speed
+	changeX := cam.TempTargetX - targetX
+	changeY := cam.TempTargetY - targetY
+	originalToX, originalToY := targetX, targetY
+	maxChange := maxSpeed * smoothTime
+	maxChangeSq := maxChange * maxChange
+
+	// Limit change length
+	if sqDist := changeX*changeX + changeY*changeY; sqDist > maxChangeSq {
+		dist := math.Sqrt(sqDist)
+		changeX = changeX / dist * maxChange
+		changeY = changeY / dist * maxChange
+	}
+
+	targetX = cam.TempTargetX - changeX
+	targetY = cam.TempTargetY - changeY
+
+	// Calculate velocity and output with exponential decay
+	tempVelocityX := (cam.CurrentVelocityX + omega*changeX) * cam.dt
+	tempVelocityY := (cam.CurrentVelocityY + omega*changeY) * cam.dt
+	cam.CurrentVelocityX = (cam.CurrentVelocityX - omega*tempVelocityX) * exp
+	cam.CurrentVelocityY = (cam.CurrentVelocityY - omega*tempVelocityY) * exp
+	outputX := targetX + (changeX+tempVelocityX)*exp
+	outputY := targetY + (changeY+tempVelocityY)*exp
+
+	// Check if we've overshot the target
+	origMinusCurrentX := originalToX - cam.TempTargetX
+	origMinusCurrentY := originalToY - cam.TempTargetY
+	outMinusOrigX := outputX - originalToX
+	outMinusOrigY := outputY - originalToY
+
+	if origMinusCurrentX*outMinusOrigX+origMinusCurrentY*outMinusOrigY > 0 {
+		outputX, outputY = originalToX, originalToY
+		cam.CurrentVelocityX, cam.CurrentVelocityY = 0, 0
+	}
+
+	return outputX, outputY
+}
+
+// springX moves the X axis towards the target with second-order dynamics.
+func (cam *Camera) springX(targetX float64) float64 {
+	o := cam.SmoothOptions
+	cam.TempTargetX, cam.CurrentVelocityX = secondOrder(
+		cam.TempTargetX, cam.CurrentVelocityX, cam.PrevTargetX, targetX,
+		o.SpringFrequencyX, o.SpringDampingX, o.SpringResponseX, cam.dt)
+	return cam.TempTargetX
+}
+
+// springY moves the Y axis towards the target with second-order dynamics.
+func (cam *Camera) springY(targetY float64) float64 {
+	o := cam.SmoothOptions
+	cam.TempTargetY, cam.CurrentVelocityY = secondOrder(
+		cam.TempTargetY, cam.CurrentVelocityY, cam.PrevTargetY, targetY,
+		o.SpringFrequencyY, o.SpringDampingY, o.SpringResponseY, cam.dt)
+	return cam.TempTargetY
+}
+
+// axisSmoothTypes returns the smoothing types of the X and Y axes.
+//
+// A disabled axis is None.
+func (cam *Camera) axisSmoothTypes() (typeX, typeY SmoothType) {
+	typeX, typeY = cam.SmoothType, cam.SmoothType
+	if cam.PerAxisSmoothType {
+		typeX, typeY = cam.SmoothTypeX, cam.SmoothTypeY
+	}
+	if z := cam.activeZone; z != nil && z.OverrideSmoothType {
+		typeX, typeY = z.SmoothType, z.SmoothType
+	}
+	if cam.XAxisSmoothingDisabled {
+		typeX = None
+	}
+	if cam.YAxisSmoothingDisabled {
+		typeY = None
+	}
+	return typeX, typeY
+}
+
+// smoothX returns the smoothed X position for the smoothing type.
+func (cam *Camera) smoothX(t SmoothType, targetX float64) float64 {
+	switch t {
+	case Lerp:
+		speed := directional(cam.SmoothOptions.LerpSpeedX, cam.SmoothOptions.LerpSpeedNegX, targetX-cam.TempTargetX)
+		return lerp(cam.TempTargetX, targetX, lerpStep(speed, cam.dt))
+	case SmoothDamp:
+		return cam.smoothDampX(targetX)
+	case Spring:
+		return cam.springX(targetX)
+	default:
+		return targetX
+	}
+}
+
+// smoothY returns the smoothed Y position for the smoothing type.
+func (cam *Camera) smoothY(t SmoothType, targetY float64) float64 {
+	switch t {
+	case Lerp:
+		speed := directional(cam.SmoothOptions.LerpSpeedY, cam.SmoothOptions.LerpSpeedNegY, targetY-cam.TempTargetY)
+		return lerp(cam.TempTargetY, targetY, lerpStep(speed, cam.dt))
+	case SmoothDamp:
+		return cam.smoothDampY(targetY)
+	case Spring:
+		return cam.springY(targetY)
+	default:
+		return targetY
+	}
+}
+
 // LookAt aligns the midpoint of the camera viewport to the target.
 //
 // Camera motion smoothing is only applied with this method.
 // Use this function only once in Update() and change only the (targetX, targetY)
 func (cam *Camera) LookAt(targetX, targetY float64) {
-	switch cam.SmoothType {
-	case SmoothDamp:
-		if !cam.XAxisSmoothingDisabled && !cam.YAxisSmoothingDisabled {
-			cam.TempTargetX = cam.smoothDampX(targetX)
-			cam.TempTargetY = cam.smoothDampY(targetY)
-			cam.X = cam.TempTargetX
-			cam.Y = cam.TempTargetY
-		} else if !cam.XAxisSmoothingDisabled && cam.YAxisSmoothingDisabled {
-			cam.TempTargetX = cam.smoothDampX(targetX)
-			cam.X = cam.TempTargetX
-			cam.Y = targetY
-		} else if cam.XAxisSmoothingDisabled && !cam.YAxisSmoothingDisabled {
-			cam.TempTargetY = cam.smoothDampY(targetY)
-			cam.Y = cam.TempTargetY
-			cam.X = targetX
-		} else {
-			cam.X = targetX
-			cam.Y = targetY
+	cam.LookAtDt(targetX, targetY, deltaTime)
+}
+
+// LookAtDt is like LookAt() with a variable time step dt in seconds, e.g. the frame time of a variable rate game loop.
+//
+// Smoothing, trauma decay and blends advance by dt. Lerp speeds are per 1/60 second and are scaled to dt.
+// The shake noise advances by one frame per call. 0 or negative dt means DeltaTime.
+func (cam *Camera) LookAtDt(targetX, targetY, dt float64) {
+	cam.lookAt(targetX, targetY, targetY, dt)
+}
+
+// lookAt runs the LookAt() pipeline. targetY is the raw target and followY is the vertical target the camera follows.
+func (cam *Camera) lookAt(targetX, targetY, followY, dt float64) {
+	if dt <= 0 {
+		dt = deltaTime
+	}
+	cam.dt = dt
+	cam.viewPivot = false
+	cam.updatePresetBlend()
+	cam.updateZones(targetX, targetY)
+	cam.clampZoom()
+	cam.lastZoom = cam.ZoomFactor
+	cam.updateAnchor()
+	cam.InputTargetX, cam.InputTargetY = targetX, targetY
+	if !cam.GroundLock {
+		// seeds the ground height for the first locked frame
+		cam.GroundLockY = targetY
+	}
+	targetY = followY
+	if cam.AutoScroll != nil {
+		targetX, targetY = cam.autoScrollTarget()
+	} else {
+		targetX, targetY = cam.applyDeadZone(targetX, targetY)
+		if cam.Rail != nil {
+			targetX, targetY = cam.Rail.Closest(targetX, targetY)
 		}
-	case Lerp:
-		if !cam.XAxisSmoothingDisabled && !cam.YAxisSmoothingDisabled {
-			cam.TempTargetX = lerp(cam.TempTargetX, targetX, cam.SmoothOptions.LerpSpeedX)
-			cam.TempTargetY = lerp(cam.TempTargetY, targetY, cam.SmoothOptions.LerpSpeedY)
-			cam.X = cam.TempTargetX
-			cam.Y = cam.TempTargetY
-		} else if !cam.XAxisSmoothingDisabled && cam.YAxisSmoothingDisabled {
-			cam.TempTargetX = lerp(cam.TempTargetX, targetX, cam.SmoothOptions.LerpSpeedX)
-			cam.X = cam.TempTargetX
-			cam.Y = targetY
-		} else if cam.XAxisSmoothingDisabled && !cam.YAxisSmoothingDisabled {
-			cam.TempTargetY = lerp(cam.TempTargetY, targetY, cam.SmoothOptions.LerpSpeedY)
-			cam.Y = cam.TempTargetY
-			cam.X = targetX
-		} else {
-			cam.X = targetX
-			cam.Y = targetY
+		if cam.FlipScreen != nil {
+			targetX, targetY = cam.flipScreenTarget(targetX, targetY)
 		}
-	case None:
-		cam.X = targetX
-		cam.Y = targetY
-	default:
-		cam.X = targetX
-		cam.Y = targetY
 	}
+	targetX, targetY = cam.zoneTarget(targetX, targetY)
+
+	if opts := cam.zoneSmoothOptions(); opts != nil {
+		// the zone smoothing options are used only for this frame
+		defer func(o *SmoothOptions) { cam.SmoothOptions = o }(cam.SmoothOptions)
+		cam.SmoothOptions = opts
+	}
+
+	typeX, typeY := cam.axisSmoothTypes()
+	if typeX == SmoothDamp && typeY == SmoothDamp && cam.SmoothOptions.SmoothDampRadial {
+		cam.TempTargetX, cam.TempTargetY = cam.smoothDampRadial(targetX, targetY)
+	} else {
+		cam.TempTargetX = cam.smoothX(typeX, targetX)
+		cam.TempTargetY = cam.smoothY(typeY, targetY)
+	}
+	if !cam.HardLimit.Empty() && cam.AutoScroll == nil {
+		cam.applyHardLimit()
+	}
+	cam.applyBounds()
+	cam.X = cam.TempTargetX
+	cam.Y = cam.TempTargetY
+
+	cam.PrevTargetX, cam.PrevTargetY = targetX, targetY
+
+	cam.applyShake()
+
+	if cam.Recorder != nil {
+		cam.Recorder.record(cam)
+	}
+}
+
+// applyShake advances the shake by one frame and adds the shake and center offsets to the camera position.
+func (cam *Camera) applyShake() {
 	if cam.ShakeEnabled {
-		if cam.Trauma > 0 {
-			var shake = math.Pow(cam.Trauma, 2)
-			noiseValueX := fastnoise.Value3D(cam.Tick*cam.ShakeOptions.TimeScale, 0, 0, cam.ShakeOptions.Noise)
-			noiseValueY := fastnoise.Value3D(0, cam.Tick*cam.ShakeOptions.TimeScale, 0, cam.ShakeOptions.Noise)
-			noiseValueAngle := fastnoise.Value3D(0, 0, cam.Tick*cam.ShakeOptions.TimeScale, cam.ShakeOptions.Noise)
-
-			cam.TraumaOffsetX = noiseValueX * cam.ShakeOptions.MaxX * shake
-			cam.TraumaOffsetY = noiseValueY * cam.ShakeOptions.MaxY * shake
-			cam.ActualAngle = noiseValueAngle * cam.ShakeOptions.MaxAngle * shake
-
-			noiseValueZoom := fastnoise.Value3D(cam.Tick*cam.ShakeOptions.TimeScale+noise3DOffset, 0, 0, cam.ShakeOptions.Noise)
-			cam.ZoomFactorShake = noiseValueZoom * cam.ShakeOptions.MaxZoomFactor * shake
+		traumaX := min(cam.Trauma+cam.TraumaX, 1)
+		traumaY := min(cam.Trauma+cam.TraumaY, 1)
+		traumaAngle := min(cam.Trauma+cam.TraumaAngle, 1)
+		traumaZoom := min(cam.Trauma+cam.TraumaZoom, 1)
+
+		if traumaX > 0 || traumaY > 0 || traumaAngle > 0 || traumaZoom > 0 {
+			t := cam.Tick * cam.ShakeOptions.TimeScale
+			noiseValueX := fastnoise.Value3D(t, 0, 0, cam.ShakeOptions.Noise)
+			noiseValueY := fastnoise.Value3D(0, t, 0, cam.ShakeOptions.Noise)
+			noiseValueAngle := fastnoise.Value3D(0, 0, t, cam.ShakeOptions.Noise)
+
+			cam.TraumaOffsetX = noiseValueX * cam.ShakeOptions.MaxX * cam.ShakeOptions.shake(traumaX)
+			cam.TraumaOffsetY = noiseValueY * cam.ShakeOptions.MaxY * cam.ShakeOptions.shake(traumaY)
+			cam.ActualAngle = noiseValueAngle * cam.ShakeOptions.MaxAngle * cam.ShakeOptions.shake(traumaAngle)
+
+			noiseValueZoom := fastnoise.Value3D(t+noise3DOffset, 0, 0, cam.ShakeOptions.Noise)
+			cam.ZoomFactorShake = noiseValueZoom * cam.ShakeOptions.MaxZoomFactor * cam.ShakeOptions.shake(traumaZoom)
 			cam.ZoomFactorShake *= cam.ZoomFactor
 			cam.ZoomFactorShake += cam.ZoomFactor
 
-			// clamp
-			cam.Trauma = min(max(cam.Trauma-(deltaTime*cam.ShakeOptions.Decay), 0), 1)
+			// decay
+			cam.Trauma = cam.ShakeOptions.decay(cam.Trauma, cam.dt)
+			cam.TraumaX = cam.ShakeOptions.decay(cam.TraumaX, cam.dt)
+			cam.TraumaY = cam.ShakeOptions.decay(cam.TraumaY, cam.dt)
+			cam.TraumaAngle = cam.ShakeOptions.decay(cam.TraumaAngle, cam.dt)
+			cam.TraumaZoom = cam.ShakeOptions.decay(cam.TraumaZoom, cam.dt)
 
 		} else {
+			cam.TraumaOffsetX, cam.TraumaOffsetY = 0, 0
 			cam.ActualAngle = 0.0
 			cam.ZoomFactorShake = cam.ZoomFactor
 		}
@@ -270,11 +605,7 @@ func (cam *Camera) LookAt(targetX, targetY float64) {
 		cam.X += cam.CenterOffsetX
 		cam.Y += cam.CenterOffsetY
 
-		// tick
-		cam.Tick += deltaTime
-		if cam.Tick > 1000000 {
-			cam.Tick = 0
-		}
+		cam.advanceShake()
 
 	} else {
 		cam.ZoomFactorShake = cam.ZoomFactor
@@ -284,10 +615,137 @@ func (cam *Camera) LookAt(targetX, targetY float64) {
 		cam.Y += cam.CenterOffsetY
 
 		cam.Trauma = 0
+		cam.TraumaX, cam.TraumaY, cam.TraumaAngle, cam.TraumaZoom = 0, 0, 0, 0
 		cam.TraumaOffsetX, cam.TraumaOffsetY = 0, 0
 	}
 }
 
+// advanceShake advances the shake frame and Tick by the time step in frames of 1/60 second.
+func (cam *Camera) advanceShake() {
+	// the fraction of a frame is kept in Tick
+	frames := cam.Tick/deltaTime - float64(cam.ShakeFrame%tickWrapFrames) + cam.dt/deltaTime
+	n := math.Floor(frames + 1e-9)
+	frames -= n
+	if frames < 1e-9 {
+		frames = 0
+	}
+	cam.ShakeFrame += uint64(max(n, 0))
+	cam.Tick = (float64(cam.ShakeFrame%tickWrapFrames) + frames) * deltaTime
+}
+
+// applyDeadZone returns the target moved towards the camera center by the dead zone.
+func (cam *Camera) applyDeadZone(targetX, targetY float64) (float64, float64) {
+	if cam.DeadZoneX > 0 {
+		targetX = deadZone(cam.TempTargetX, targetX, cam.DeadZoneX)
+	}
+	if cam.DeadZoneY > 0 {
+		targetY = deadZone(cam.TempTargetY, targetY, cam.DeadZoneY)
+	}
+	return targetX, targetY
+}
+
+// applyHardLimit moves the smoothed camera center so the target stays inside the HardLimit.
+func (cam *Camera) applyHardLimit() {
+	hl := cam.HardLimit
+	// the camera center range that keeps the target inside the limit
+	minX, minY := cam.centerAt(cam.InputTargetX, cam.InputTargetY, hl.Right()*cam.Width, hl.Bottom()*cam.Height)
+	maxX, maxY := cam.centerAt(cam.InputTargetX, cam.InputTargetY, hl.X*cam.Width, hl.Y*cam.Height)
+	x := min(max(cam.TempTargetX, minX), maxX)
+	y := min(max(cam.TempTargetY, minY), maxY)
+	// the smoothing velocity is stopped on the clamped axis, so it does not push the camera after the target reverses
+	if x != cam.TempTargetX {
+		cam.CurrentVelocityX = 0
+	}
+	if y != cam.TempTargetY {
+		cam.CurrentVelocityY = 0
+	}
+	cam.TempTargetX, cam.TempTargetY = x, y
+}
+
+// screenPivot returns the zoom and rotation pivot in screen-space.
+func (cam *Camera) screenPivot() (float64, float64) {
+	if cam.viewPivot {
+		return cam.viewPivotX * cam.Width, cam.viewPivotY * cam.Height
+	}
+	switch cam.PivotMode {
+	case PivotScreen:
+		return cam.PivotX * cam.Width, cam.PivotY * cam.Height
+	case PivotWorld:
+		// the screen position of the world pivot before zoom and rotation
+		return cam.PivotX - cam.X, cam.PivotY - cam.Y
+	default:
+		return -cam.CenterOffsetX, -cam.CenterOffsetY
+	}
+}
+
+// centerAt returns the camera center (without shake) that places the world position at the screen position, ignoring rotation.
+func (cam *Camera) centerAt(worldX, worldY, screenX, screenY float64) (float64, float64) {
+	zoom := cam.ZoomFactor
+	if zoom <= 0 {
+		zoom = 1
+	}
+	if cam.PivotMode == PivotWorld {
+		return (worldX-cam.PivotX)*zoom + cam.PivotX - cam.CenterOffsetX - screenX,
+			(worldY-cam.PivotY)*zoom + cam.PivotY - cam.CenterOffsetY - screenY
+	}
+	px, py := cam.screenPivot()
+	return worldX - cam.CenterOffsetX - px - (screenX-px)/zoom,
+		worldY - cam.CenterOffsetY - py - (screenY-py)/zoom
+}
+
+// worldAt returns the world position at the screen position for the camera center (without shake), ignoring rotation.
+func (cam *Camera) worldAt(centerX, centerY, screenX, screenY float64) (float64, float64) {
+	zoom := cam.ZoomFactor
+	if zoom <= 0 {
+		zoom = 1
+	}
+	if cam.PivotMode == PivotWorld {
+		return cam.PivotX + (centerX+cam.CenterOffsetX+screenX-cam.PivotX)/zoom,
+			cam.PivotY + (centerY+cam.CenterOffsetY+screenY-cam.PivotY)/zoom
+	}
+	px, py := cam.screenPivot()
+	return centerX + cam.CenterOffsetX + px + (screenX-px)/zoom,
+		centerY + cam.CenterOffsetY + py + (screenY-py)/zoom
+}
+
+// SetAnchor sets the framing anchor. See AnchorX.
+func (cam *Camera) SetAnchor(x, y float64) {
+	cam.AnchorX, cam.AnchorY = x, y
+	cam.anchorSet = true
+}
+
+// anchor returns the framing anchor. An unset anchor (0, 0 on a zero Camera) is the viewport center.
+func (cam *Camera) anchor() (float64, float64) {
+	if !cam.anchorSet && cam.AnchorX == 0 && cam.AnchorY == 0 {
+		return 0.5, 0.5
+	}
+	return cam.AnchorX, cam.AnchorY
+}
+
+// updateAnchor moves the current anchor towards the anchor and updates the center offset.
+func (cam *Camera) updateAnchor() {
+	anchorX, anchorY := cam.anchor()
+	if cam.AnchorLerpSpeed > 0 {
+		t := lerpStep(cam.AnchorLerpSpeed, cam.dt)
+		cam.CurrentAnchorX = lerp(cam.CurrentAnchorX, anchorX, t)
+		cam.CurrentAnchorY = lerp(cam.CurrentAnchorY, anchorY, t)
+	} else {
+		cam.CurrentAnchorX, cam.CurrentAnchorY = anchorX, anchorY
+	}
+	cam.CenterOffsetX = -(cam.Width * cam.CurrentAnchorX)
+	cam.CenterOffsetY = -(cam.Height * cam.CurrentAnchorY)
+}
+
+// clampZoom limits the ZoomFactor to MinZoom and MaxZoom.
+func (cam *Camera) clampZoom() {
+	if cam.MinZoom > 0 {
+		cam.ZoomFactor = max(cam.ZoomFactor, cam.MinZoom)
+	}
+	if cam.MaxZoom > 0 {
+		cam.ZoomFactor = min(cam.ZoomFactor, cam.MaxZoom)
+	}
+}
+
 // AddTrauma adds trauma. Factor is in the range [0-1]
 func (cam *Camera) AddTrauma(factor float64) {
 	if cam.ShakeEnabled {
@@ -295,6 +753,27 @@ func (cam *Camera) AddTrauma(factor float64) {
 	}
 }
 
+// AddChannelTrauma adds trauma only to the given shake channels. Factor is in the range [0-1]
+//
+// Example: cam.AddChannelTrauma(0.8, kamera.ShakeY) shakes vertically without rotating.
+func (cam *Camera) AddChannelTrauma(factor float64, channels ShakeChannel) {
+	if !cam.ShakeEnabled {
+		return
+	}
+	if channels&ShakeX != 0 {
+		cam.TraumaX = min(max(cam.TraumaX+factor, 0), 1)
+	}
+	if channels&ShakeY != 0 {
+		cam.TraumaY = min(max(cam.TraumaY+factor, 0), 1)
+	}
+	if channels&ShakeAngle != 0 {
+		cam.TraumaAngle = min(max(cam.TraumaAngle+factor, 0), 1)
+	}
+	if channels&ShakeZoom != 0 {
+		cam.TraumaZoom = min(max(cam.TraumaZoom+factor, 0), 1)
+	}
+}
+
 // Right returns the right edge position of the camera in world-space.
 func (cam *Camera) Right() float64 {
 	return cam.X + cam.Width
@@ -311,6 +790,7 @@ func (cam *Camera) Bottom() float64 {
 func (cam *Camera) SetTopLeft(x, y float64) {
 	cam.X, cam.Y = x, y
 	cam.TempTargetX, cam.TempTargetY = cam.Center()
+	cam.PrevTargetX, cam.PrevTargetY = cam.TempTargetX, cam.TempTargetY
 
 }
 
@@ -321,10 +801,18 @@ func (cam *Camera) SetTopLeft(x, y float64) {
 // Can be used to cancel follow camera and teleport to target.
 func (cam *Camera) SetCenter(x, y float64) {
 	cam.TempTargetX, cam.TempTargetY = x, y
+	cam.PrevTargetX, cam.PrevTargetY = x, y
+	cam.CurrentVelocityX, cam.CurrentVelocityY = 0, 0
+	cam.GroundLockY = y
+	cam.flipScreen = flipScreenState{}
+	cam.autoScroll = autoScrollState{}
 	cam.LookAt(x, y)
 }
 
-// Center returns center point of the camera in world-space
+// Center returns center point of the camera in world-space.
+//
+// It is the point at the framing anchor (see AnchorX), the viewport center by default.
+// If the pivot is not the anchor, the point at the anchor differs while zoomed.
 func (cam *Camera) Center() (X float64, Y float64) {
 	return cam.X - cam.CenterOffsetX, cam.Y - cam.CenterOffsetY
 }
@@ -342,8 +830,8 @@ func (cam *Camera) CenterY() float64 {
 // SetSize sets camera rectangle size
 func (cam *Camera) SetSize(w, h float64) {
 	cam.Width, cam.Height = w, h
-	cam.CenterOffsetX = -(w * 0.5)
-	cam.CenterOffsetY = -(h * 0.5)
+	cam.CenterOffsetX = -(w * cam.CurrentAnchorX)
+	cam.CenterOffsetY = -(h * cam.CurrentAnchorY)
 }
 
 // Reset resets rotation and zoom factor to zero
@@ -369,16 +857,6 @@ SmoothDampMaxSpeedY: %.2f`
 
 // String returns camera values as string
 func (cam *Camera) String() string {
-	smoothTypeStr := ""
-	switch cam.SmoothType {
-	case None:
-		smoothTypeStr = "None"
-	case Lerp:
-		smoothTypeStr = "Lerp"
-	case SmoothDamp:
-		smoothTypeStr = "SmoothDamp"
-	}
-
 	return fmt.Sprintf(
 		cameraStats,
 		cam.X-cam.CenterOffsetX,
@@ -389,7 +867,7 @@ func (cam *Camera) String() string {
 		cam.ActualAngle,
 		cam.ZoomFactorShake,
 		cam.ShakeEnabled,
-		smoothTypeStr,
+		cam.smoothTypeString(),
 		cam.SmoothOptions.LerpSpeedX,
 		cam.SmoothOptions.LerpSpeedY,
 		cam.SmoothOptions.SmoothDampTimeX,
@@ -399,6 +877,13 @@ func (cam *Camera) String() string {
 	)
 }
 
+func (cam *Camera) smoothTypeString() string {
+	if cam.PerAxisSmoothType {
+		return cam.SmoothTypeX.String() + "/" + cam.SmoothTypeY.String()
+	}
+	return cam.SmoothType.String()
+}
+
 // ScreenToWorld converts screen-space coordinates to world-space
 func (cam *Camera) ScreenToWorld(screenX, screenY int) (worldX float64, worldY float64) {
 	g := ebiten.GeoM{}
@@ -422,11 +907,12 @@ func (cam *Camera) ApplyCameraTransformToPoint(x, y float64) (float64, float64)
 
 // ApplyCameraTransform applies geometric transformation to given geoM
 func (cam *Camera) ApplyCameraTransform(g *ebiten.GeoM) {
-	g.Translate(-cam.X, -cam.Y)                                           // camera movement
-	g.Translate(cam.CenterOffsetX, cam.CenterOffsetY)                     // rotate and scale from center.
-	g.Rotate(cam.ActualAngle)                                             // rotate
-	g.Scale(cam.ZoomFactorShake, cam.ZoomFactorShake)                     // apply zoom factor
-	g.Translate(math.Abs(cam.CenterOffsetX), math.Abs(cam.CenterOffsetY)) // restore center translation
+	px, py := cam.screenPivot()
+	g.Translate(-cam.X, -cam.Y)                       // camera movement
+	g.Translate(-px, -py)                             // rotate and scale from the pivot.
+	g.Rotate(cam.ActualAngle)                         // rotate
+	g.Scale(cam.ZoomFactorShake, cam.ZoomFactorShake) // apply zoom factor
+	g.Translate(px, py)                               // restore pivot translation
 }
 
 // Draw applies the Camera's geometric transformation then draws the object on the screen with drawing options.
@@ -450,6 +936,48 @@ type ShakeOptions struct {
 	MaxZoomFactor float64 // Zoom factor strength [1-0]. 0 means disabled
 	TimeScale     float64 // Noise time domain speed
 	Decay         float64 // Decay for trauma
+	// DecayType is the trauma decay type. Default is LinearDecay
+	DecayType DecayType
+	// TraumaExponent is the exponent of the trauma response curve (shake = trauma^exponent).
+	//
+	// Default value is 2. 0 means default.
+	TraumaExponent float64
+	// TraumaCurve is a custom trauma response curve. It maps trauma [0-1] to shake [0-1].
+	//
+	// If it is not nil, TraumaExponent is ignored.
+	TraumaCurve func(trauma float64) float64
+}
+
+// shake returns the shake amount of the trauma using the response curve.
+func (so *ShakeOptions) shake(trauma float64) float64 {
+	if trauma <= 0 {
+		return 0
+	}
+	if so.TraumaCurve != nil {
+		return so.TraumaCurve(trauma)
+	}
+	exponent := so.TraumaExponent
+	if exponent <= 0 {
+		exponent = 2
+	}
+	return math.Pow(trauma, exponent)
+}
+
+// decay returns the trauma decayed for the time step dt.
+func (so *ShakeOptions) decay(trauma, dt float64) float64 {
+	if trauma <= 0 {
+		return 0
+	}
+	switch so.DecayType {
+	case ExponentialDecay:
+		trauma *= math.Exp(-so.Decay * dt)
+		if trauma < traumaEpsilon {
+			trauma = 0
+		}
+	default:
+		trauma -= dt * so.Decay
+	}
+	return min(max(trauma, 0), 1) // clamp
 }
 
 // SmoothOptions is the camera movement smoothing options.
@@ -481,6 +1009,69 @@ type SmoothOptions struct {
 	//
 	// Default value is 1000
 	SmoothDampMaxSpeedY float64
+	// LerpSpeedNegX is the X-axis Lerp speed used when the target is in the negative (left) direction.
+	//
+	// 0 means LerpSpeedX is used. Default value is 0
+	LerpSpeedNegX float64
+	// LerpSpeedNegY is the Y-axis Lerp speed used when the target is in the negative (up) direction.
+	//
+	// 0 means LerpSpeedY is used. Default value is 0
+	LerpSpeedNegY float64
+	// SmoothDampTimeNegX is the X-axis SmoothDamp time used when the target is in the negative (left) direction.
+	//
+	// 0 means SmoothDampTimeX is used. Default value is 0
+	SmoothDampTimeNegX float64
+	// SmoothDampTimeNegY is the Y-axis SmoothDamp time used when the target is in the negative (up) direction.
+	//
+	// 0 means SmoothDampTimeY is used. Default value is 0
+	SmoothDampTimeNegY float64
+	// SmoothDampMaxSpeedNegX is the X-axis SmoothDamp max speed used when the target is in the negative (left) direction.
+	//
+	// 0 means SmoothDampMaxSpeedX is used. Default value is 0
+	SmoothDampMaxSpeedNegX float64
+	// SmoothDampMaxSpeedNegY is the Y-axis SmoothDamp max speed used when the target is in the negative (up) direction.
+	//
+	// 0 means SmoothDampMaxSpeedY is used. Default value is 0
+	SmoothDampMaxSpeedNegY float64
+
+	// SmoothDampRadial smooths along the displacement vector instead of each axis independently,
+	// so the total speed is clamped and diagonal paths stay straight.
+	//
+	// It uses SmoothDampTimeX and SmoothDampMaxSpeedX, and applies only when both axes are smoothed.
+	// The negative direction options are picked by the dominant axis of the displacement:
+	// SmoothDampTimeNegX and SmoothDampMaxSpeedNegX when it is mostly to the left,
+	// SmoothDampTimeNegY and SmoothDampMaxSpeedNegY when it is mostly up.
+	// Default value is false
+	SmoothDampRadial bool
+
+	// SpringFrequencyX is the X-Axis natural frequency of the Spring in Hz.
+	//
+	// A bigger value will respond faster. Default value is 2
+	SpringFrequencyX float64
+	// SpringFrequencyY is the Y-Axis natural frequency of the Spring in Hz.
+	//
+	// A bigger value will respond faster. Default value is 2
+	SpringFrequencyY float64
+	// SpringDampingX is the X-Axis damping ratio of the Spring.
+	//
+	// 0 is undamped, values in (0-1) overshoot, 1 is critically damped and values above 1 are sluggish.
+	// Default value is 1
+	SpringDampingX float64
+	// SpringDampingY is the Y-Axis damping ratio of the Spring.
+	//
+	// 0 is undamped, values in (0-1) overshoot, 1 is critically damped and values above 1 are sluggish.
+	// Default value is 1
+	SpringDampingY float64
+	// SpringResponseX is the X-Axis initial response of the Spring.
+	//
+	// 0 starts slowly, 1 responds immediately, values above 1 overshoot and negative values anticipate.
+	// Default value is 0
+	SpringResponseX float64
+	// SpringResponseY is the Y-Axis initial response of the Spring.
+	//
+	// 0 starts slowly, 1 responds immediately, values above 1 overshoot and negative values anticipate.
+	// Default value is 0
+	SpringResponseY float64
 }
 
 func DefaultSmoothOptions() *SmoothOptions {
@@ -491,9 +1082,60 @@ func DefaultSmoothOptions() *SmoothOptions {
 		SmoothDampTimeY:     0.2,
 		SmoothDampMaxSpeedX: 1000.0,
 		SmoothDampMaxSpeedY: 1000.0,
+		SpringFrequencyX:    2.0,
+		SpringFrequencyY:    2.0,
+		SpringDampingX:      1.0,
+		SpringDampingY:      1.0,
 	}
 }
 
 func lerp(start, end, t float64) float64 {
 	return start + t*(end-start)
 }
+
+// lerpStep returns the Lerp factor for the time step dt of a per-frame (1/60 second) Lerp speed.
+func lerpStep(speed, dt float64) float64 {
+	if dt == deltaTime || speed <= 0 || speed >= 1 {
+		return speed
+	}
+	return 1 - math.Pow(1-speed, dt/deltaTime)
+}
+
+// secondOrder advances a second-order dynamics system by dt and returns the new position and velocity.
+//
+// f is the natural frequency in Hz, z is the damping ratio and r is the initial response.
+// Steps longer than DeltaTime are split into substeps, which keeps the integration stable
+// for any dt and for a changing dt.
+func secondOrder(pos, vel, prevTarget, target, f, z, r, dt float64) (float64, float64) {
+	if f <= 0 || dt <= 0 {
+		return target, 0
+	}
+	w := 2 * math.Pi * f
+	k1 := z / (math.Pi * f)
+	k3 := r * z / w
+	targetVel := (target - prevTarget) / dt
+	n := math.Ceil(dt/deltaTime - 1e-9)
+	h := dt / n
+	k2 := max(1/(w*w), h*h/2+h*k1/2, h*k1) // clamp k2 to guarantee stability
+	for range int(n) {
+		pos += h * vel
+		vel += h * (target + k3*targetVel - pos - k1*vel) / k2
+	}
+	return pos, vel
+}
+
+// directional returns neg if delta is negative and neg is set, otherwise pos.
+func directional(pos, neg, delta float64) float64 {
+	if delta < 0 && neg > 0 {
+		return neg
+	}
+	return pos
+}
+
+func deadZone(center, target, halfSize float64) float64 {
+	delta := target - center
+	if math.Abs(delta) <= halfSize {
+		return center
+	}
+	return target - math.Copysign(halfSize, delta)
+}
@@ -1,6 +1,7 @@
 package kamera_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/setanarut/kamera/v2"
@@ -45,3 +46,374 @@ func TestSetCenter(t *testing.T) {
 		t.Error()
 	}
 }
+
+func TestAddChannelTrauma(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ShakeEnabled = true
+	k.AddChannelTrauma(1, kamera.ShakeY)
+	if k.TraumaY != 1 || k.TraumaX != 0 || k.Trauma != 0 {
+		t.Error()
+	}
+	for range 10 {
+		k.LookAt(0, 0)
+		if k.TraumaOffsetX != 0 || k.ActualAngle != 0 || k.ZoomFactorShake != 1 {
+			t.Error()
+		}
+	}
+	if k.TraumaY >= 1 {
+		t.Error()
+	}
+}
+
+func TestExponentialDecay(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ShakeEnabled = true
+	k.ShakeOptions.DecayType = kamera.ExponentialDecay
+	k.ShakeOptions.Decay = 60
+	k.AddTrauma(1)
+	k.LookAt(0, 0)
+	if math.Abs(k.Trauma-math.Exp(-1)) > 1e-9 {
+		t.Error(k.Trauma)
+	}
+}
+
+func TestSpring(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.Spring
+	maxX := 0.0
+	for range 300 {
+		k.LookAt(100, 0)
+		maxX = max(maxX, k.CenterX())
+	}
+	if maxX > 100 || math.Abs(k.CenterX()-100) > 1e-3 {
+		t.Error(maxX, k.CenterX())
+	}
+
+	k.SetCenter(0, 0)
+	k.SmoothOptions.SpringDampingX = 0.3
+	maxX = 0
+	for range 300 {
+		k.LookAt(100, 0)
+		maxX = max(maxX, k.CenterX())
+	}
+	if maxX <= 100 {
+		t.Error(maxX)
+	}
+}
+
+func TestSpringStability(t *testing.T) {
+	// the critical time step of the underdamped spring below is about 0.12 seconds
+	for _, dt := range []float64{0.1, 0.5, 1, 2} {
+		k := kamera.NewCamera(0, 0, 100, 100)
+		k.SmoothType = kamera.Spring
+		k.SmoothOptions.SpringDampingX = 0.3
+		k.SmoothOptions.SpringResponseX = 2
+		maxX := 0.0
+		for range 500 {
+			k.LookAtDt(100, 0, dt)
+			maxX = max(maxX, math.Abs(k.CenterX()))
+		}
+		if math.IsNaN(k.CenterX()) || maxX > 1000 || math.Abs(k.CenterX()-100) > 1e-3 {
+			t.Error(dt, maxX, k.CenterX())
+		}
+	}
+
+	// variable time step
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.Spring
+	for i := range 600 {
+		k.LookAtDt(100, 0, []float64{0.005, 0.3, 0.016, 1}[i%4])
+	}
+	if math.Abs(k.CenterX()-100) > 1e-3 {
+		t.Error(k.CenterX())
+	}
+
+	// the fixed step is the same as LookAt()
+	a, b := kamera.NewCamera(0, 0, 100, 100), kamera.NewCamera(0, 0, 100, 100)
+	a.SmoothType, b.SmoothType = kamera.Spring, kamera.Spring
+	for range 10 {
+		a.LookAt(50, 20)
+		b.LookAtDt(50, 20, kamera.DeltaTime)
+	}
+	if a.Snapshot() != b.Snapshot() {
+		t.Error(a.Snapshot(), b.Snapshot())
+	}
+}
+
+func TestSmoothDampRadial(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.SmoothDamp
+	k.SmoothOptions.SmoothDampRadial = true
+	k.SmoothOptions.SmoothDampMaxSpeedX = 60
+	for range 60 {
+		x, y := k.Center()
+		k.LookAt(1000, 1000)
+		nx, ny := k.Center()
+		if step := math.Hypot(nx-x, ny-y); step > 1+1e-9 {
+			t.Fatal(step)
+		}
+		if math.Abs(nx-ny) > 1e-9 {
+			t.Fatal("path is not straight")
+		}
+	}
+	for range 600 {
+		k.SmoothOptions.SmoothDampMaxSpeedX = 1000
+		k.LookAt(10, 20)
+	}
+	if x, y := k.Center(); math.Abs(x-10) > 1e-6 || math.Abs(y-20) > 1e-6 {
+		t.Error(x, y)
+	}
+}
+
+func TestSmoothDampRadialDirectional(t *testing.T) {
+	step := func(tx, ty float64) float64 {
+		k := kamera.NewCamera(0, 0, 100, 100)
+		k.SmoothType = kamera.SmoothDamp
+		k.SmoothOptions.SmoothDampRadial = true
+		k.SmoothOptions.SmoothDampMaxSpeedX = 60
+		k.SmoothOptions.SmoothDampMaxSpeedNegX = 120
+		k.SmoothOptions.SmoothDampMaxSpeedNegY = 180
+		k.LookAt(tx, ty)
+		return math.Hypot(k.CenterX(), k.CenterY())
+	}
+	for _, tc := range []struct{ x, y, want float64 }{
+		{1000, 100, 1},
+		{-1000, 100, 2},
+		{100, 1000, 1},
+		{100, -1000, 3},
+	} {
+		// the first step is proportional to the max speed while the change is clamped
+		if got := step(tc.x, tc.y) / step(1000, 100); math.Abs(got-tc.want) > 1e-9 {
+			t.Error(tc, got)
+		}
+	}
+}
+
+func TestPerAxisSmoothType(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.PerAxisSmoothType = true
+	k.SmoothTypeX = kamera.SmoothDamp
+	k.SmoothTypeY = kamera.Lerp
+	k.SmoothOptions.LerpSpeedY = 0.5
+	k.LookAt(100, 100)
+	x, y := k.Center()
+	if y != 50 || x <= 0 || x >= 100 {
+		t.Error(x, y)
+	}
+	k.SmoothTypeY = kamera.None
+	k.LookAt(100, 100)
+	if k.CenterY() != 100 {
+		t.Error()
+	}
+}
+
+func TestDirectionalSmoothing(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.Lerp
+	k.SmoothOptions.LerpSpeedY = 0.5
+	k.SmoothOptions.LerpSpeedNegY = 0.1
+	k.LookAt(0, 100)
+	if k.CenterY() != 50 {
+		t.Error(k.CenterY())
+	}
+	k.SetCenter(0, 0)
+	k.LookAt(0, -100)
+	if math.Abs(k.CenterY()+10) > 1e-9 {
+		t.Error(k.CenterY())
+	}
+
+	k.SmoothType = kamera.SmoothDamp
+	k.SmoothOptions.SmoothDampMaxSpeedNegX = 60
+	k.SetCenter(0, 0)
+	k.LookAt(-1000, 0)
+	if math.Abs(k.CenterX()) > 1+1e-9 {
+		t.Error(k.CenterX())
+	}
+	k.SetCenter(0, 0)
+	k.LookAt(1000, 0)
+	if k.CenterX() <= 1 {
+		t.Error(k.CenterX())
+	}
+}
+
+func TestHardLimit(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.SmoothDamp
+	k.SmoothOptions.SmoothDampTimeX = 10
+	k.HardLimit = kamera.Rect{X: 0.3, Y: 0.2, Width: 0.4, Height: 0.6}
+	k.LookAt(500, 0)
+	// target at 70% of the view
+	if k.CenterX() != 480 {
+		t.Error(k.CenterX())
+	}
+	k.LookAt(400, 0)
+	if x := k.CenterX(); x < 380 || x > 420 {
+		t.Error(x)
+	}
+}
+
+func TestZeroAnchor(t *testing.T) {
+	k := &kamera.Camera{
+		Width:         100,
+		Height:        80,
+		ZoomFactor:    1,
+		SmoothOptions: kamera.DefaultSmoothOptions(),
+		ShakeOptions:  kamera.DefaultCameraShakeOptions(),
+	}
+	k.LookAt(10, 20)
+	if x, y := k.Center(); x != 10 || y != 20 || k.CenterOffsetX != -50 {
+		t.Error(x, y, k.CenterOffsetX)
+	}
+}
+
+func TestTopLeftAnchor(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 80)
+	k.AnchorX, k.AnchorY = 0, 0
+	k.LookAt(10, 20)
+	if x, y := k.ApplyCameraTransformToPoint(10, 20); math.Abs(x) > 1e-9 || math.Abs(y) > 1e-9 || k.CenterOffsetX != 0 {
+		t.Error(x, y, k.CenterOffsetX)
+	}
+
+	z := &kamera.Camera{Width: 100, Height: 80, ZoomFactor: 1}
+	z.SetAnchor(0, 0)
+	z.LookAt(10, 20)
+	if z.CenterOffsetX != 0 || z.CenterOffsetY != 0 {
+		t.Error(z.CenterOffsetX, z.CenterOffsetY)
+	}
+	data, err := z.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b kamera.Camera
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	b.LookAt(10, 20)
+	if b.AnchorX != 0 || b.CenterOffsetX != 0 {
+		t.Error(b.AnchorX, b.CenterOffsetX)
+	}
+}
+
+func TestHardLimitVelocity(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.SmoothDamp
+	k.SmoothOptions.SmoothDampTimeX = 1
+	k.HardLimit = kamera.Rect{X: 0.3, Y: 0.2, Width: 0.4, Height: 0.6}
+	x := 0.0
+	for range 60 {
+		x += 20
+		k.LookAt(x, 0)
+	}
+	// the camera follows the reversed target without the velocity built up while clamped
+	prev := k.CenterX()
+	x = prev - 10
+	for range 60 {
+		x -= 2
+		k.LookAt(x, 0)
+		if k.CenterX() > prev {
+			t.Fatal(k.CenterX(), prev)
+		}
+		prev = k.CenterX()
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 90, 90)
+	k.AnchorX = 1.0 / 3
+	k.ZoomFactor = 2
+	k.Angle = 0.5
+	k.LookAt(100, 50)
+	x, y := k.ApplyCameraTransformToPoint(100, 50)
+	if math.Abs(x-30) > 1e-9 || math.Abs(y-45) > 1e-9 {
+		t.Error(x, y)
+	}
+	if wx, wy := k.ScreenToWorld(30, 45); math.Abs(wx-100) > 1e-9 || math.Abs(wy-50) > 1e-9 {
+		t.Error(wx, wy)
+	}
+
+	// bounds with the anchor: 15 world units left of the target, 30 right of it
+	k.Angle = 0
+	k.Bounds = kamera.Rect{X: 0, Y: 0, Width: 1000, Height: 1000}
+	k.LookAt(990, 500)
+	if k.CenterX() != 970 {
+		t.Error(k.CenterX())
+	}
+
+	k.AnchorLerpSpeed = 0.5
+	k.AnchorX = 2.0 / 3
+	k.LookAt(500, 500)
+	if k.CurrentAnchorX <= 1.0/3 || k.CurrentAnchorX >= 2.0/3 {
+		t.Error(k.CurrentAnchorX)
+	}
+}
+
+func TestLookAtDtAnchorAndShake(t *testing.T) {
+	a := kamera.NewCamera(0, 0, 90, 90)
+	b := kamera.NewCamera(0, 0, 90, 90)
+	for _, k := range []*kamera.Camera{a, b} {
+		k.ShakeEnabled = true
+		k.AnchorLerpSpeed = 0.1
+		k.AnchorX, k.AnchorY = 0.2, 0.8
+	}
+	for range 60 {
+		a.LookAtDt(0, 0, 1.0/60)
+	}
+	for range 30 {
+		b.LookAtDt(0, 0, 1.0/30)
+	}
+	if math.Abs(a.CurrentAnchorX-b.CurrentAnchorX) > 1e-9 || a.ShakeFrame != 60 || b.ShakeFrame != 60 || a.Tick != b.Tick {
+		t.Error(a.CurrentAnchorX, b.CurrentAnchorX, a.ShakeFrame, b.ShakeFrame, a.Tick, b.Tick)
+	}
+	// half frames are kept in Tick
+	b.LookAtDt(0, 0, 1.0/120)
+	if b.ShakeFrame != 60 || math.Abs(b.Tick-60.5/60) > 1e-9 {
+		t.Error(b.ShakeFrame, b.Tick)
+	}
+	b.LookAtDt(0, 0, 1.0/120)
+	if b.ShakeFrame != 61 || b.Tick != 61.0/60 {
+		t.Error(b.ShakeFrame, b.Tick)
+	}
+}
+
+func TestPivot(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.PivotMode = kamera.PivotWorld
+	k.PivotX, k.PivotY = 60, 40
+	k.ZoomFactor = 3
+	k.Angle = 0.7
+	k.LookAt(50, 50)
+	// the world pivot stays at its unzoomed screen position
+	x, y := k.ApplyCameraTransformToPoint(60, 40)
+	if math.Abs(x-60) > 1e-9 || math.Abs(y-40) > 1e-9 {
+		t.Error(x, y)
+	}
+	if wx, wy := k.ScreenToWorld(60, 40); math.Abs(wx-60) > 1e-9 || math.Abs(wy-40) > 1e-9 {
+		t.Error(wx, wy)
+	}
+
+	// the view rectangle matches ScreenToWorld without rotation
+	k.Angle = 0
+	k.LookAt(50, 50)
+	r := k.ViewRect()
+	if wx, wy := k.ScreenToWorld(0, 0); math.Abs(r.X-wx) > 1e-9 || math.Abs(r.Y-wy) > 1e-9 {
+		t.Error(r, wx, wy)
+	}
+
+	// bounds keep the zoomed view inside
+	k.Bounds = kamera.Rect{X: 0, Y: 0, Width: 1000, Height: 1000}
+	k.LookAt(-500, -500)
+	if r := k.ViewRect(); math.Abs(r.X) > 1e-9 || math.Abs(r.Y) > 1e-9 {
+		t.Error(r)
+	}
+
+	k = kamera.NewCamera(50, 50, 100, 100)
+	k.PivotMode = kamera.PivotScreen
+	k.ZoomFactor = 2
+	k.LookAt(50, 50)
+	if wx, wy := k.ScreenToWorld(0, 0); math.Abs(wx) > 1e-9 || math.Abs(wy) > 1e-9 {
+		t.Error(wx, wy)
+	}
+	if r := k.ViewRect(); r != (kamera.Rect{X: 0, Y: 0, Width: 50, Height: 50}) {
+		t.Error(r)
+	}
+}
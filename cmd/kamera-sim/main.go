@@ -0,0 +1,284 @@
+// Command kamera-sim runs a target trajectory through Camera.LookAt() without a window
+// and writes the camera trajectory as CSV and an SVG plot of target vs. camera per axis.
+//
+// The input is a CSV of time,x,y rows (time in seconds). A header row is allowed.
+//
+// Usage:
+//
+//	kamera-sim -in target.csv -out camera.csv -svg plot.svg -preset platformer
+//	kamera-sim -in target.csv -smooth SmoothDamp -damp-time-x 0.3 -max-speed-x 500
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+// sample is a target position at a time.
+type sample struct {
+	t, x, y float64
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("kamera-sim: ")
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
+
+// run runs the simulation with the command line arguments. The CSV is written to stdout if -out is "-".
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("kamera-sim", flag.ContinueOnError)
+	in := fs.String("in", "", "input target trajectory CSV (time,x,y)")
+	out := fs.String("out", "-", "output camera trajectory CSV, - for stdout")
+	svg := fs.String("svg", "", "output SVG plot of target vs. camera per axis")
+	presetFile := fs.String("presets", "", "preset JSON file to register before applying -preset")
+	preset := fs.String("preset", "", "preset name, one of: "+strings.Join(kamera.PresetNames(), ", "))
+	width := fs.Float64("width", 640, "camera width")
+	height := fs.Float64("height", 360, "camera height")
+	smooth := fs.String("smooth", "", "smoothing type: None, Lerp, SmoothDamp or Spring")
+	smoothX := fs.String("smooth-x", "", "X-axis smoothing type, enables Camera.PerAxisSmoothType")
+	smoothY := fs.String("smooth-y", "", "Y-axis smoothing type, enables Camera.PerAxisSmoothType")
+	lerpX := fs.Float64("lerp-x", 0, "SmoothOptions.LerpSpeedX")
+	lerpY := fs.Float64("lerp-y", 0, "SmoothOptions.LerpSpeedY")
+	dampTimeX := fs.Float64("damp-time-x", 0, "SmoothOptions.SmoothDampTimeX")
+	dampTimeY := fs.Float64("damp-time-y", 0, "SmoothOptions.SmoothDampTimeY")
+	maxSpeedX := fs.Float64("max-speed-x", 0, "SmoothOptions.SmoothDampMaxSpeedX")
+	maxSpeedY := fs.Float64("max-speed-y", 0, "SmoothOptions.SmoothDampMaxSpeedY")
+	springFreqX := fs.Float64("spring-freq-x", 0, "SmoothOptions.SpringFrequencyX")
+	springFreqY := fs.Float64("spring-freq-y", 0, "SmoothOptions.SpringFrequencyY")
+	springDampX := fs.Float64("spring-damp-x", 0, "SmoothOptions.SpringDampingX")
+	springDampY := fs.Float64("spring-damp-y", 0, "SmoothOptions.SpringDampingY")
+	springRespX := fs.Float64("spring-resp-x", 0, "SmoothOptions.SpringResponseX")
+	springRespY := fs.Float64("spring-resp-y", 0, "SmoothOptions.SpringResponseY")
+	deadZoneX := fs.Float64("dead-zone-x", 0, "Camera.DeadZoneX")
+	deadZoneY := fs.Float64("dead-zone-y", 0, "Camera.DeadZoneY")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	samples, err := readTrajectory(*in)
+	if err != nil {
+		return err
+	}
+
+	cam := kamera.NewCamera(samples[0].x, samples[0].y, *width, *height)
+	if *presetFile != "" {
+		if err := kamera.LoadPresetFile(*presetFile); err != nil {
+			return err
+		}
+	}
+	if *preset != "" {
+		if err := cam.ApplyPreset(*preset); err != nil {
+			return err
+		}
+	}
+	// Flags override the preset only if they are set.
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "smooth":
+			flagErr = errors.Join(flagErr, cam.SmoothType.UnmarshalText([]byte(*smooth)))
+		case "smooth-x":
+			flagErr = errors.Join(flagErr, cam.SmoothTypeX.UnmarshalText([]byte(*smoothX)))
+			if !cam.PerAxisSmoothType {
+				cam.PerAxisSmoothType = true
+				cam.SmoothTypeY = cam.SmoothType
+			}
+		case "smooth-y":
+			flagErr = errors.Join(flagErr, cam.SmoothTypeY.UnmarshalText([]byte(*smoothY)))
+			if !cam.PerAxisSmoothType {
+				cam.PerAxisSmoothType = true
+				cam.SmoothTypeX = cam.SmoothType
+			}
+		case "lerp-x":
+			cam.SmoothOptions.LerpSpeedX = *lerpX
+		case "lerp-y":
+			cam.SmoothOptions.LerpSpeedY = *lerpY
+		case "damp-time-x":
+			cam.SmoothOptions.SmoothDampTimeX = *dampTimeX
+		case "damp-time-y":
+			cam.SmoothOptions.SmoothDampTimeY = *dampTimeY
+		case "max-speed-x":
+			cam.SmoothOptions.SmoothDampMaxSpeedX = *maxSpeedX
+		case "max-speed-y":
+			cam.SmoothOptions.SmoothDampMaxSpeedY = *maxSpeedY
+		case "spring-freq-x":
+			cam.SmoothOptions.SpringFrequencyX = *springFreqX
+		case "spring-freq-y":
+			cam.SmoothOptions.SpringFrequencyY = *springFreqY
+		case "spring-damp-x":
+			cam.SmoothOptions.SpringDampingX = *springDampX
+		case "spring-damp-y":
+			cam.SmoothOptions.SpringDampingY = *springDampY
+		case "spring-resp-x":
+			cam.SmoothOptions.SpringResponseX = *springRespX
+		case "spring-resp-y":
+			cam.SmoothOptions.SpringResponseY = *springRespY
+		case "dead-zone-x":
+			cam.DeadZoneX = *deadZoneX
+		case "dead-zone-y":
+			cam.DeadZoneY = *deadZoneY
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+	cam.ShakeEnabled = false
+	cam.SetCenter(samples[0].x, samples[0].y)
+
+	const dt = kamera.DeltaTime
+	duration := samples[len(samples)-1].t - samples[0].t
+	cam.Recorder = kamera.NewTraceRecorder(duration + 2*dt)
+	for i := 0; float64(i)*dt <= duration; i++ {
+		x, y := targetAt(samples, samples[0].t+float64(i)*dt)
+		cam.LookAt(x, y)
+	}
+	frames := cam.Recorder.Frames(0)
+
+	if *out == "-" {
+		if err := cam.Recorder.WriteCSV(stdout, 0); err != nil {
+			return err
+		}
+	} else if err := createFile(*out, func(w io.Writer) error { return cam.Recorder.WriteCSV(w, 0) }); err != nil {
+		return err
+	}
+	if *svg != "" {
+		if err := createFile(*svg, func(w io.Writer) error { return writeSVG(w, frames) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createFile creates the file, writes it with write and closes it. The close error is returned too.
+func createFile(path string, write func(w io.Writer) error) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	return write(f)
+}
+
+// readTrajectory reads time,x,y rows sorted by time.
+func readTrajectory(path string) ([]sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 3
+	r.TrimLeadingSpace = true
+	var samples []sample
+	for line := 1; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var s sample
+		var errs [3]error
+		s.t, errs[0] = strconv.ParseFloat(rec[0], 64)
+		s.x, errs[1] = strconv.ParseFloat(rec[1], 64)
+		s.y, errs[2] = strconv.ParseFloat(rec[2], 64)
+		if err := errors.Join(errs[:]...); err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if len(samples) > 0 && s.t < samples[len(samples)-1].t {
+			return nil, fmt.Errorf("%s:%d: time is not sorted", path, line)
+		}
+		samples = append(samples, s)
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("%s: no samples", path)
+	}
+	return samples, nil
+}
+
+// targetAt returns the linearly interpolated target position at time t, clamped to the first and last samples.
+func targetAt(samples []sample, t float64) (float64, float64) {
+	if first := samples[0]; t <= first.t {
+		return first.x, first.y
+	}
+	for i := 1; i < len(samples); i++ {
+		a, b := samples[i-1], samples[i]
+		if t <= b.t {
+			if b.t == a.t {
+				return b.x, b.y
+			}
+			k := (t - a.t) / (b.t - a.t)
+			return a.x + (b.x-a.x)*k, a.y + (b.y-a.y)*k
+		}
+	}
+	last := samples[len(samples)-1]
+	return last.x, last.y
+}
+
+// writeSVG writes two plots (X and Y axis) of target and camera position over time.
+func writeSVG(w io.Writer, frames []kamera.TraceFrame) error {
+	const plotW, plotH, pad = 800.0, 260.0, 30.0
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" font-family="monospace" font-size="12">`+"\n",
+		plotW+pad*2, (plotH+pad*2)*2)
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	axes := []struct {
+		name           string
+		target, camera func(kamera.TraceFrame) float64
+	}{
+		{"X", func(f kamera.TraceFrame) float64 { return f.TargetX }, func(f kamera.TraceFrame) float64 { return f.X }},
+		{"Y", func(f kamera.TraceFrame) float64 { return f.TargetY }, func(f kamera.TraceFrame) float64 { return f.Y }},
+	}
+	for i, axis := range axes {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, f := range frames {
+			lo = min(lo, axis.target(f), axis.camera(f))
+			hi = max(hi, axis.target(f), axis.camera(f))
+		}
+		if hi-lo < 1e-9 {
+			lo, hi = lo-1, hi+1
+		}
+		top := pad + float64(i)*(plotH+pad*2)
+		fmt.Fprintf(w, `<rect x="%v" y="%v" width="%v" height="%v" fill="none" stroke="#ccc"/>`+"\n", pad, top, plotW, plotH)
+		fmt.Fprintf(w, `<text x="%v" y="%v">%s axis [%.2f, %.2f] (red: target, blue: camera)</text>`+"\n", pad, top-8, axis.name, lo, hi)
+		for _, line := range []struct {
+			value func(kamera.TraceFrame) float64
+			color string
+		}{{axis.target, "#e33"}, {axis.camera, "#33e"}} {
+			fmt.Fprintf(w, `<polyline fill="none" stroke="%s" stroke-width="1.5" points="`, line.color)
+			for j, f := range frames {
+				x := pad + plotW*float64(j)/float64(max(len(frames)-1, 1))
+				y := top + plotH - plotH*(line.value(f)-lo)/(hi-lo)
+				fmt.Fprintf(w, "%.2f,%.2f ", x, y)
+			}
+			fmt.Fprintln(w, `"/>`)
+		}
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func writeTemp(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadTrajectory(t *testing.T) {
+	samples, err := readTrajectory(writeTemp(t, "in.csv", "time,x,y\n0,1,2\n0.5, 3, 4\n"))
+	if err != nil || len(samples) != 2 || samples[1] != (sample{0.5, 3, 4}) {
+		t.Error(samples, err)
+	}
+	if _, err := readTrajectory(writeTemp(t, "in.csv", "0,0,0\n1,0,0\n0.5,0,0\n")); err == nil || !strings.Contains(err.Error(), ":3: time is not sorted") {
+		t.Error(err)
+	}
+	if _, err := readTrajectory(writeTemp(t, "in.csv", "0,0,0\n1,x,0\n")); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Error(err)
+	}
+	if _, err := readTrajectory(writeTemp(t, "in.csv", "0,0\n")); err == nil {
+		t.Error("short row")
+	}
+	if _, err := readTrajectory(writeTemp(t, "in.csv", "time,x,y\n")); err == nil {
+		t.Error("no samples")
+	}
+}
+
+func TestTargetAt(t *testing.T) {
+	samples := []sample{{0, 0, 0}, {1, 10, 20}, {1, 30, 30}, {2, 30, 40}}
+	for _, c := range []struct{ t, x, y float64 }{
+		{-1, 0, 0},
+		{0.5, 5, 10},
+		{1, 10, 20},
+		{1.5, 30, 35},
+		{5, 30, 40},
+	} {
+		if x, y := targetAt(samples, c.t); x != c.x || y != c.y {
+			t.Error(c.t, x, y)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	frames := []kamera.TraceFrame{{TargetX: 0, X: 0}, {TargetX: 10, X: 5, Y: 1}}
+	if err := writeSVG(&buf, frames); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") || strings.Count(svg, "<polyline") != 4 {
+		t.Error(svg)
+	}
+}
+
+func TestRun(t *testing.T) {
+	in := writeTemp(t, "in.csv", "0,0,0\n1,100,0\n")
+	dir := t.TempDir()
+	out, svg := filepath.Join(dir, "out.csv"), filepath.Join(dir, "plot.svg")
+	if err := run([]string{"-in", in, "-out", out, "-svg", svg, "-smooth", "Lerp"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header and one row per frame
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 62 {
+		t.Error(len(lines))
+	}
+	if data, err := os.ReadFile(svg); err != nil || !strings.HasSuffix(string(data), "</svg>\n") {
+		t.Error(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := run([]string{"-in", in}, &stdout); err != nil || stdout.Len() == 0 {
+		t.Error(err)
+	}
+	if err := run([]string{"-in", in, "-smooth", "Bogus"}, &stdout); err == nil {
+		t.Error("invalid smoothing type")
+	}
+}
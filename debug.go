@@ -0,0 +1,178 @@
+package kamera
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// DebugOptions holds the element toggles and colors of the DrawDebug() overlay.
+type DebugOptions struct {
+	// Target draws the last LookAt() target (InputTargetX, InputTargetY).
+	Target bool
+	// Smoothed draws the smoothed camera center (TempTargetX, TempTargetY).
+	Smoothed bool
+	// DeadZone draws the dead zone rectangle around the smoothed center.
+	DeadZone bool
+	// HardLimit draws the hard limit rectangle in screen-space.
+	HardLimit bool
+	// TargetLine draws the line from the smoothed center to the target.
+	TargetLine bool
+	// Bounds draws the camera world bounds.
+	Bounds bool
+	// Zones draws the camera zone rectangles. The active zone is crossed out.
+	Zones bool
+	// Velocity draws the smoothing velocity arrow (CurrentVelocityX, CurrentVelocityY).
+	Velocity bool
+	// Shake draws the shake offset arrow (TraumaOffsetX, TraumaOffsetY).
+	Shake bool
+	// TraumaBar draws the trauma bar at the bottom-left of the screen.
+	TraumaBar bool
+
+	// VelocityScale is the length of the velocity arrow in seconds of motion. Default is 0.25
+	VelocityScale float64
+	// ShakeScale scales the shake offset arrow. Default is 4
+	ShakeScale float64
+
+	TargetColor     color.Color
+	SmoothedColor   color.Color
+	DeadZoneColor   color.Color
+	HardLimitColor  color.Color
+	TargetLineColor color.Color
+	BoundsColor     color.Color
+	ZoneColor       color.Color
+	VelocityColor   color.Color
+	ShakeColor      color.Color
+	TraumaColor     color.Color
+}
+
+// DefaultDebugOptions returns debug options with all elements enabled.
+func DefaultDebugOptions() *DebugOptions {
+	return &DebugOptions{
+		Target:          true,
+		Smoothed:        true,
+		DeadZone:        true,
+		HardLimit:       true,
+		TargetLine:      true,
+		Bounds:          true,
+		Zones:           true,
+		Velocity:        true,
+		Shake:           true,
+		TraumaBar:       true,
+		VelocityScale:   0.25,
+		ShakeScale:      4,
+		TargetColor:     color.RGBA{255, 80, 80, 255},
+		SmoothedColor:   color.RGBA{80, 255, 80, 255},
+		DeadZoneColor:   color.RGBA{0, 220, 255, 255},
+		HardLimitColor:  color.RGBA{255, 80, 0, 255},
+		TargetLineColor: color.RGBA{255, 220, 0, 255},
+		BoundsColor:     color.RGBA{255, 0, 255, 255},
+		ZoneColor:       color.RGBA{120, 120, 255, 255},
+		VelocityColor:   color.RGBA{255, 140, 0, 255},
+		ShakeColor:      color.RGBA{255, 255, 255, 255},
+		TraumaColor:     color.RGBA{255, 60, 60, 255},
+	}
+}
+
+// DrawDebug draws the camera internals onto the screen for tuning.
+//
+// World-space elements are drawn with the camera transform. Call it after LookAt() and after the world is drawn.
+// If opts is nil, DefaultDebugOptions() is used.
+func (cam *Camera) DrawDebug(screen *ebiten.Image, opts *DebugOptions) {
+	if opts == nil {
+		opts = DefaultDebugOptions()
+	}
+	g := ebiten.GeoM{}
+	cam.ApplyCameraTransform(&g)
+
+	if opts.Bounds && !cam.Bounds.Empty() {
+		b := cam.Bounds
+		strokeWorldRect(screen, &g, b.X, b.Y, b.Right(), b.Bottom(), opts.BoundsColor)
+	}
+	if opts.Zones {
+		for _, z := range cam.Zones {
+			r := z.Rect
+			strokeWorldRect(screen, &g, r.X, r.Y, r.Right(), r.Bottom(), opts.ZoneColor)
+			if z == cam.activeZone {
+				strokeWorldLine(screen, &g, r.X, r.Y, r.Right(), r.Bottom(), opts.ZoneColor)
+				strokeWorldLine(screen, &g, r.Right(), r.Y, r.X, r.Bottom(), opts.ZoneColor)
+			}
+		}
+	}
+	if opts.DeadZone && (cam.DeadZoneX > 0 || cam.DeadZoneY > 0) {
+		strokeWorldRect(screen, &g,
+			cam.TempTargetX-cam.DeadZoneX, cam.TempTargetY-cam.DeadZoneY,
+			cam.TempTargetX+cam.DeadZoneX, cam.TempTargetY+cam.DeadZoneY,
+			opts.DeadZoneColor)
+	}
+	if opts.HardLimit && !cam.HardLimit.Empty() {
+		hl := cam.HardLimit
+		vector.StrokeRect(screen,
+			float32(hl.X*cam.Width), float32(hl.Y*cam.Height),
+			float32(hl.Width*cam.Width), float32(hl.Height*cam.Height),
+			1, opts.HardLimitColor, false)
+	}
+	if opts.TargetLine {
+		strokeWorldLine(screen, &g, cam.TempTargetX, cam.TempTargetY, cam.InputTargetX, cam.InputTargetY, opts.TargetLineColor)
+	}
+	if opts.Velocity {
+		scale := opts.VelocityScale
+		strokeWorldArrow(screen, &g, cam.TempTargetX, cam.TempTargetY,
+			cam.TempTargetX+cam.CurrentVelocityX*scale, cam.TempTargetY+cam.CurrentVelocityY*scale,
+			opts.VelocityColor)
+	}
+	if opts.Shake {
+		scale := opts.ShakeScale
+		strokeWorldArrow(screen, &g, cam.TempTargetX, cam.TempTargetY,
+			cam.TempTargetX+cam.TraumaOffsetX*scale, cam.TempTargetY+cam.TraumaOffsetY*scale,
+			opts.ShakeColor)
+	}
+	if opts.Smoothed {
+		x, y := g.Apply(cam.TempTargetX, cam.TempTargetY)
+		vector.StrokeCircle(screen, float32(x), float32(y), 4, 1, opts.SmoothedColor, true)
+	}
+	if opts.Target {
+		x, y := g.Apply(cam.InputTargetX, cam.InputTargetY)
+		vector.StrokeLine(screen, float32(x-5), float32(y), float32(x+5), float32(y), 1, opts.TargetColor, true)
+		vector.StrokeLine(screen, float32(x), float32(y-5), float32(x), float32(y+5), 1, opts.TargetColor, true)
+	}
+	if opts.TraumaBar {
+		const barWidth, barHeight, margin = 100, 6, 10
+		trauma := max(cam.Trauma, cam.TraumaX, cam.TraumaY, cam.TraumaAngle, cam.TraumaZoom)
+		y := float32(screen.Bounds().Dy() - margin - barHeight)
+		vector.FillRect(screen, margin, y, float32(barWidth*min(trauma, 1)), barHeight, opts.TraumaColor, false)
+		vector.StrokeRect(screen, margin, y, barWidth, barHeight, 1, opts.TraumaColor, false)
+	}
+}
+
+func strokeWorldLine(dst *ebiten.Image, g *ebiten.GeoM, x0, y0, x1, y1 float64, clr color.Color) {
+	sx0, sy0 := g.Apply(x0, y0)
+	sx1, sy1 := g.Apply(x1, y1)
+	vector.StrokeLine(dst, float32(sx0), float32(sy0), float32(sx1), float32(sy1), 1, clr, true)
+}
+
+// strokeWorldRect draws the world-space rectangle as four lines, so it is correct when the camera is rotated.
+func strokeWorldRect(dst *ebiten.Image, g *ebiten.GeoM, left, top, right, bottom float64, clr color.Color) {
+	strokeWorldLine(dst, g, left, top, right, top, clr)
+	strokeWorldLine(dst, g, right, top, right, bottom, clr)
+	strokeWorldLine(dst, g, right, bottom, left, bottom, clr)
+	strokeWorldLine(dst, g, left, bottom, left, top, clr)
+}
+
+func strokeWorldArrow(dst *ebiten.Image, g *ebiten.GeoM, x0, y0, x1, y1 float64, clr color.Color) {
+	sx0, sy0 := g.Apply(x0, y0)
+	sx1, sy1 := g.Apply(x1, y1)
+	length := math.Hypot(sx1-sx0, sy1-sy0)
+	if length < 1 {
+		return
+	}
+	vector.StrokeLine(dst, float32(sx0), float32(sy0), float32(sx1), float32(sy1), 1, clr, true)
+	head := min(8, length*0.5)
+	angle := math.Atan2(sy1-sy0, sx1-sx0)
+	for _, a := range [2]float64{angle + math.Pi*0.8, angle - math.Pi*0.8} {
+		hx, hy := sx1+math.Cos(a)*head, sy1+math.Sin(a)*head
+		vector.StrokeLine(dst, float32(sx1), float32(sy1), float32(hx), float32(hy), 1, clr, true)
+	}
+}
@@ -0,0 +1,43 @@
+package kamera
+
+import "math"
+
+// Easing maps the linear progress in the range [0-1] to the eased progress.
+type Easing func(t float64) float64
+
+// EaseLinear is no easing.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseSmoothstep accelerates and decelerates with the smoothstep curve.
+func EaseSmoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// EaseInQuad accelerates from zero velocity.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero velocity.
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutCubic accelerates and decelerates with a cubic curve.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)*0.5
+}
+
+// ease returns the eased progress, clamped to [0-1]. A nil easing is EaseSmoothstep.
+func ease(e Easing, t float64) float64 {
+	t = min(max(t, 0), 1)
+	if e == nil {
+		return EaseSmoothstep(t)
+	}
+	return e(t)
+}
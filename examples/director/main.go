@@ -34,6 +34,7 @@ ArrowUp/Down  Zoom 2x
 Backspace     Reset camera               
 R             Rotate                     
 C             Toggle DrawWithColorM() %v                
+G             Toggle DrawDebug()          
 `
 
 var (
@@ -47,6 +48,7 @@ var (
 	spriteSheet                         *ebiten.Image
 
 	colormEnabled bool
+	debugEnabled  bool
 )
 
 type Game struct{}
@@ -61,6 +63,10 @@ func (g *Game) Update() error {
 		colormEnabled = !colormEnabled
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		debugEnabled = !debugEnabled
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
 		mainCamera.ZoomFactor *= 2
 	}
@@ -125,6 +131,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		mainCamera.Draw(spriteSheet, dio, screen)
 	}
 
+	if debugEnabled {
+		mainCamera.DrawDebug(screen, nil)
+	}
+
 	// Draw camera crosshair
 	cx, cy := float32(w/2), float32(h/2)
 	vector.StrokeLine(screen, cx-100, cy, cx+100, cy, 1, color.White, true)
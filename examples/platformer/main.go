@@ -76,31 +76,15 @@ func init() {
 	cam.ShakeEnabled = true
 	cam.SmoothType = kamera.SmoothDamp
 	cam.SmoothOptions.SmoothDampTimeX = 0.15
+	cam.DeadZoneX = CameraDeadZoneX
 
 }
-func calculateDeadZoneX(playerX float64) float64 {
-	camCenterX := cam.X - cam.CenterOffsetX
-	deltaX := playerX - camCenterX
-	targetX := playerX
-	if CameraDeadZoneX > 0 {
-		if math.Abs(deltaX) <= CameraDeadZoneX {
-			targetX = camCenterX
-		} else {
-			if deltaX > 0 {
-				targetX = playerX - CameraDeadZoneX
-			} else {
-				targetX = playerX + CameraDeadZoneX
-			}
-		}
-	}
-	return targetX
-}
 
 type Game struct{}
 
 func (g *Game) Update() error {
 
-	cam.LookAt(calculateDeadZoneX(player.Pos.X+CameraDeadZoneOffset), ScreenHeight/2)
+	cam.LookAt(player.Pos.X+CameraDeadZoneOffset, ScreenHeight/2)
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
 		cam.AddTrauma(1.0)
@@ -0,0 +1,114 @@
+package kamera
+
+import "math"
+
+// FlipScreenOptions holds the flip-screen (screen-by-screen) camera mode settings.
+//
+// The camera center is quantized to a grid of view-sized cells starting at the world origin.
+// The camera jumps to the next cell when the target crosses the edge of the view.
+type FlipScreenOptions struct {
+	// X enables the flip-screen mode on the X axis.
+	X bool
+	// Y enables the flip-screen mode on the Y axis.
+	Y bool
+	// Margin is the overlap of neighbouring cells in world units. Default is 0
+	Margin float64
+	// SlideDuration is the slide animation duration in seconds. 0 means the camera jumps instantly.
+	SlideDuration float64
+	// Easing is the slide easing. Default is EaseSmoothstep
+	Easing Easing
+}
+
+// flipScreenState is the current cell and slide animation of the flip-screen mode.
+type flipScreenState struct {
+	init           bool
+	cellX, cellY   int
+	x, y           float64
+	fromX, fromY   float64
+	elapsed        float64
+	sliding        bool
+	slideX, slideY bool
+}
+
+// DefaultFlipScreenOptions returns the default flip-screen options with both axes enabled.
+func DefaultFlipScreenOptions() *FlipScreenOptions {
+	return &FlipScreenOptions{
+		X:      true,
+		Y:      true,
+		Easing: EaseSmoothstep,
+	}
+}
+
+// FlipScreenCell returns the current flip-screen cell.
+func (cam *Camera) FlipScreenCell() (x, y int) {
+	return cam.flipScreen.cellX, cam.flipScreen.cellY
+}
+
+// IsFlipScreenSliding returns true while the flip-screen slide animation is in progress.
+func (cam *Camera) IsFlipScreenSliding() bool {
+	return cam.flipScreen.sliding
+}
+
+// flipScreenTarget returns the camera center of the flip-screen cell of the target, with the slide animation applied.
+func (cam *Camera) flipScreenTarget(targetX, targetY float64) (float64, float64) {
+	o := cam.FlipScreen
+	s := &cam.flipScreen
+	zoom := cam.ZoomFactor
+	if zoom <= 0 {
+		zoom = 1
+	}
+	w, h := cam.Width/zoom, cam.Height/zoom
+
+	x, y := targetX, targetY
+	changedX, changedY := false, false
+	if o.X {
+		s.cellX, changedX = flipCell(s.cellX, targetX, w, o.Margin, s.init)
+		x, _ = cam.centerAt(float64(s.cellX)*(w-o.Margin), 0, 0, 0)
+	}
+	if o.Y {
+		s.cellY, changedY = flipCell(s.cellY, targetY, h, o.Margin, s.init)
+		_, y = cam.centerAt(0, float64(s.cellY)*(h-o.Margin), 0, 0)
+	}
+
+	if s.init && (changedX || changedY) && o.SlideDuration > 0 {
+		s.fromX, s.fromY = s.x, s.y
+		s.elapsed = 0
+		s.sliding = true
+		s.slideX, s.slideY = s.slideX || changedX, s.slideY || changedY
+	}
+	s.init = true
+
+	if s.sliding {
+		s.elapsed += cam.dt
+		if s.elapsed >= o.SlideDuration {
+			s.sliding = false
+			s.slideX, s.slideY = false, false
+		} else {
+			t := ease(o.Easing, s.elapsed/o.SlideDuration)
+			if s.slideX {
+				x = lerp(s.fromX, x, t)
+			}
+			if s.slideY {
+				y = lerp(s.fromY, y, t)
+			}
+		}
+	}
+	s.x, s.y = x, y
+	return x, y
+}
+
+// flipCell returns the cell of the position and true if it is different from the current cell.
+//
+// The current cell is kept while the position is inside its view span.
+func flipCell(cell int, pos, size, margin float64, init bool) (int, bool) {
+	step := size - margin
+	if step <= 0 {
+		return cell, false
+	}
+	left := float64(cell) * step
+	if init && pos >= left && pos < left+size {
+		return cell, false
+	}
+	next := int(math.Floor(pos / step))
+	return next, init && next != cell
+}
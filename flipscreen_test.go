@@ -0,0 +1,49 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestFlipScreen(t *testing.T) {
+	cam := kamera.NewCamera(50, 50, 100, 100)
+	cam.FlipScreen = kamera.DefaultFlipScreenOptions()
+	cam.FlipScreen.Y = false
+
+	cam.LookAt(90, 70)
+	if cam.CenterX() != 50 || cam.CenterY() != 70 {
+		t.Error(cam.CenterX(), cam.CenterY())
+	}
+	cam.LookAt(110, 70)
+	if x, _ := cam.FlipScreenCell(); x != 1 || cam.CenterX() != 150 {
+		t.Error(x, cam.CenterX())
+	}
+	cam.LookAt(-10, 70)
+	if x, _ := cam.FlipScreenCell(); x != -1 || cam.CenterX() != -50 {
+		t.Error(x, cam.CenterX())
+	}
+}
+
+func TestFlipScreenMarginAndSlide(t *testing.T) {
+	cam := kamera.NewCamera(50, 50, 100, 100)
+	cam.FlipScreen = kamera.DefaultFlipScreenOptions()
+	cam.FlipScreen.Margin = 20
+	cam.FlipScreen.SlideDuration = 0.5
+
+	cam.LookAt(50, 50)
+	cam.LookAt(101, 50)
+	if x, _ := cam.FlipScreenCell(); x != 1 || !cam.IsFlipScreenSliding() {
+		t.Error(x)
+	}
+	if x := cam.CenterX(); x <= 50 || x >= 130 {
+		t.Error(x)
+	}
+	// inside the overlap of the new cell
+	for range 30 {
+		cam.LookAt(85, 50)
+	}
+	if x, _ := cam.FlipScreenCell(); x != 1 || cam.IsFlipScreenSliding() || cam.CenterX() != 130 {
+		t.Error(x, cam.CenterX())
+	}
+}
@@ -0,0 +1,40 @@
+package kamera
+
+// LookAtGrounded is LookAt() for platformers with the ground-lock vertical follow mode.
+//
+// If GroundLock is true, the vertical target is only updated when the target is grounded (landing),
+// or when it leaves the vertical band around the last ground height (GroundLockBandUp, GroundLockBandDown).
+// This avoids vertical bobbing on every jump. If GroundLock is false, it is the same as LookAt().
+//
+// InputTargetY and the Recorder get the raw targetY. While GroundLock is false, the ground height
+// follows the target, so the lock starts from the last target when it is enabled.
+func (cam *Camera) LookAtGrounded(targetX, targetY float64, grounded bool) {
+	cam.LookAtGroundedDt(targetX, targetY, grounded, deltaTime)
+}
+
+// LookAtGroundedDt is like LookAtGrounded() with a custom time step dt in seconds. See LookAtDt().
+func (cam *Camera) LookAtGroundedDt(targetX, targetY float64, grounded bool, dt float64) {
+	followY := targetY
+	if cam.GroundLock {
+		followY = cam.groundLockY(targetY, grounded)
+	}
+	cam.lookAt(targetX, targetY, followY, dt)
+}
+
+// groundLockY returns the vertical target of the ground-lock follow mode.
+func (cam *Camera) groundLockY(targetY float64, grounded bool) float64 {
+	if grounded {
+		cam.GroundLockY = targetY
+		return targetY
+	}
+	delta := targetY - cam.GroundLockY
+	if cam.GroundLockBandUp > 0 && delta < -cam.GroundLockBandUp {
+		// jumped above the band
+		return targetY + cam.GroundLockBandUp
+	}
+	if cam.GroundLockBandDown > 0 && delta > cam.GroundLockBandDown {
+		// fell below the band
+		return targetY - cam.GroundLockBandDown
+	}
+	return cam.GroundLockY
+}
@@ -0,0 +1,80 @@
+package kamera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestLookAtGrounded(t *testing.T) {
+	k := kamera.NewCamera(0, 100, 100, 100)
+	k.GroundLock = true
+	k.GroundLockBandUp = 50
+
+	// jump inside the band
+	k.LookAtGrounded(0, 80, false)
+	if k.CenterY() != 100 {
+		t.Error(k.CenterY())
+	}
+	// jump above the band
+	k.LookAtGrounded(0, 30, false)
+	if k.CenterY() != 80 {
+		t.Error(k.CenterY())
+	}
+	// land on a higher platform
+	k.LookAtGrounded(0, 60, true)
+	if k.CenterY() != 60 || k.GroundLockY != 60 {
+		t.Error(k.CenterY())
+	}
+	// fall without band limit
+	k.LookAtGrounded(0, 500, false)
+	if k.CenterY() != 60 {
+		t.Error(k.CenterY())
+	}
+}
+
+func TestLookAtGroundedSeed(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.GroundLockBandUp = 50
+	k.LookAtGrounded(0, 200, true)
+
+	// enable the lock in the air, the first locked frame starts from the last target
+	k.GroundLock = true
+	k.LookAtGrounded(0, 180, false)
+	if k.GroundLockY != 200 || k.CenterY() != 200 {
+		t.Error(k.GroundLockY, k.CenterY())
+	}
+}
+
+func TestLookAtGroundedRecorder(t *testing.T) {
+	k := kamera.NewCamera(0, 100, 100, 100)
+	k.GroundLock = true
+	k.GroundLockBandUp = 50
+	k.Recorder = kamera.NewTraceRecorder(1)
+	k.LookAtGrounded(0, 80, false)
+	if f := k.Recorder.Frames(1)[0]; f.TargetY != 80 || f.Y != 100 {
+		t.Error(f)
+	}
+	if k.InputTargetY != 80 {
+		t.Error(k.InputTargetY)
+	}
+}
+
+func TestLookAtGroundedDt(t *testing.T) {
+	a := kamera.NewCamera(0, 100, 100, 100)
+	b := kamera.NewCamera(0, 100, 100, 100)
+	for _, k := range []*kamera.Camera{a, b} {
+		k.GroundLock = true
+		k.SmoothType = kamera.Lerp
+	}
+	for range 4 {
+		a.LookAtGrounded(40, 60, true)
+	}
+	for range 2 {
+		b.LookAtGroundedDt(40, 60, true, 1.0/30)
+	}
+	if math.Abs(a.CenterY()-b.CenterY()) > 1e-9 || b.GroundLockY != 60 {
+		t.Error(a.CenterY(), b.CenterY(), b.GroundLockY)
+	}
+}
@@ -0,0 +1,286 @@
+package kamera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// LDtk entity identifiers imported as camera data.
+const (
+	// LDtkZone is the identifier of the entities imported as camera zones.
+	LDtkZone = "CameraZone"
+	// LDtkPoint is the identifier of the entities imported as focus points.
+	LDtkPoint = "CameraPoint"
+	// LDtkRail is the identifier of the entities imported as camera rails. The rail points are the "path" field.
+	LDtkRail = "CameraRail"
+)
+
+// ErrMultipleWorlds is returned by LoadLDtk() when the project has more than one world.
+var ErrMultipleWorlds = errors.New("kamera: the project has multiple worlds")
+
+type ldtkProject struct {
+	WorldLayout    string       `json:"worldLayout"`
+	ExternalLevels bool         `json:"externalLevels"`
+	Levels         []*ldtkLevel `json:"levels"`
+	Worlds         []ldtkWorld  `json:"worlds"`
+}
+
+type ldtkWorld struct {
+	Identifier  string       `json:"identifier"`
+	WorldLayout string       `json:"worldLayout"`
+	Levels      []*ldtkLevel `json:"levels"`
+}
+
+type ldtkLevel struct {
+	Identifier      string      `json:"identifier"`
+	WorldX          float64     `json:"worldX"`
+	WorldY          float64     `json:"worldY"`
+	PxWid           float64     `json:"pxWid"`
+	PxHei           float64     `json:"pxHei"`
+	ExternalRelPath string      `json:"externalRelPath"`
+	LayerInstances  []ldtkLayer `json:"layerInstances"`
+}
+
+type ldtkLayer struct {
+	Type            string       `json:"__type"`
+	GridSize        float64      `json:"__gridSize"`
+	PxTotalOffsetX  float64      `json:"__pxTotalOffsetX"`
+	PxTotalOffsetY  float64      `json:"__pxTotalOffsetY"`
+	EntityInstances []ldtkEntity `json:"entityInstances"`
+}
+
+type ldtkEntity struct {
+	Identifier     string          `json:"__identifier"`
+	Iid            string          `json:"iid"`
+	Pivot          [2]float64      `json:"__pivot"`
+	Px             [2]float64      `json:"px"`
+	Width          float64         `json:"width"`
+	Height         float64         `json:"height"`
+	FieldInstances []ldtkFieldInst `json:"fieldInstances"`
+}
+
+type ldtkFieldInst struct {
+	Identifier string          `json:"__identifier"`
+	Value      json.RawMessage `json:"__value"`
+}
+
+type ldtkGridPoint struct {
+	Cx float64 `json:"cx"`
+	Cy float64 `json:"cy"`
+}
+
+// LoadLDtkFile loads the camera data of all levels of an LDtk project (.ldtk) file.
+//
+// See LoadLDtk().
+func LoadLDtkFile(path string) (*World, error) {
+	return LoadLDtk(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+// LoadLDtkWorldsFile loads the camera data of all worlds of an LDtk project (.ldtk) file.
+//
+// See LoadLDtkWorlds().
+func LoadLDtkWorldsFile(path string) ([]*World, error) {
+	return LoadLDtkWorlds(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+}
+
+// LoadLDtk loads the camera data of all levels of an LDtk project (.ldtk) in the file system.
+//
+// Each level becomes a Level with the level rectangle as Bounds. For GridVania and Free world layouts
+// the coordinates are the LDtk world coordinates. For LinearHorizontal and LinearVertical layouts
+// the levels are placed next to each other in file order, starting at the origin. External level files
+// are resolved relative to the project. Entities are mapped to the level data by LDtkZone, LDtkPoint
+// and LDtkRail. All entities support an optional String field "name". Zone entities support these fields:
+//
+//	priority   Int           Zone.Priority
+//	lock       String/Enum   "X", "Y" or "XY", locks the axes of the zone
+//	center     Point         Zone.CenterX and Zone.CenterY (default is the entity center)
+//	zoom       Float         Zone.Zoom
+//	smoothType String/Enum   Zone.SmoothType ("None", "Lerp", "SmoothDamp" or "Spring")
+//	blendIn    Float         Zone.BlendIn in seconds
+//	blendOut   Float         Zone.BlendOut in seconds
+//
+// Rail entities use an Array<Point> field "path" and an optional Bool field "closed".
+//
+// The levels of different LDtk worlds can overlap, so a project with multiple worlds
+// returns ErrMultipleWorlds. Use LoadLDtkWorlds() for them.
+func LoadLDtk(fsys fs.FS, name string) (*World, error) {
+	worlds, err := LoadLDtkWorlds(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	switch len(worlds) {
+	case 0:
+		return &World{}, nil
+	case 1:
+		return worlds[0], nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMultipleWorlds, name)
+}
+
+// LoadLDtkWorlds loads the camera data of an LDtk project (.ldtk) in the file system, one World per LDtk world.
+//
+// The World names are the LDtk world identifiers. The levels of a single-world project are
+// in one World with an empty name. See LoadLDtk().
+func LoadLDtkWorlds(fsys fs.FS, name string) ([]*World, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	var p ldtkProject
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("kamera: %s: %w", name, err)
+	}
+	worlds := p.Worlds
+	if len(p.Levels) > 0 {
+		worlds = append([]ldtkWorld{{WorldLayout: p.WorldLayout, Levels: p.Levels}}, worlds...)
+	}
+	var result []*World
+	for _, w := range worlds {
+		world, err := p.world(fsys, name, w)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, world)
+	}
+	return result, nil
+}
+
+// world returns the camera data of the LDtk world.
+func (p *ldtkProject) world(fsys fs.FS, name string, w ldtkWorld) (*World, error) {
+	world := &World{Name: w.Identifier}
+	// the next level origin of the linear layouts
+	var linearX, linearY float64
+	for _, l := range w.Levels {
+		if p.ExternalLevels && l.ExternalRelPath != "" {
+			src := path.Join(path.Dir(name), l.ExternalRelPath)
+			data, err := fs.ReadFile(fsys, src)
+			if err != nil {
+				return nil, fmt.Errorf("kamera: %s: level: %w", name, err)
+			}
+			l = &ldtkLevel{}
+			if err := json.Unmarshal(data, l); err != nil {
+				return nil, fmt.Errorf("kamera: %s: %w", src, err)
+			}
+		}
+		originX, originY := l.WorldX, l.WorldY
+		switch w.WorldLayout {
+		case "LinearHorizontal":
+			originX, originY = linearX, 0
+			linearX += l.PxWid
+		case "LinearVertical":
+			originX, originY = 0, linearY
+			linearY += l.PxHei
+		}
+		level, err := l.level(originX, originY)
+		if err != nil {
+			return nil, fmt.Errorf("kamera: %s: level %q: %w", name, l.Identifier, err)
+		}
+		world.Levels = append(world.Levels, level)
+	}
+	return world, nil
+}
+
+// level returns the camera data of the level with the origin at the given world position.
+func (l *ldtkLevel) level(originX, originY float64) (*Level, error) {
+	level := &Level{
+		Name:   l.Identifier,
+		Bounds: Rect{originX, originY, l.PxWid, l.PxHei},
+	}
+	for _, layer := range l.LayerInstances {
+		if layer.Type != "Entities" {
+			continue
+		}
+		ox, oy := originX+layer.PxTotalOffsetX, originY+layer.PxTotalOffsetY
+		for _, e := range layer.EntityInstances {
+			fields := ldtkFieldMap(e.FieldInstances)
+			name := e.Iid
+			err := fields.get("name", &name)
+			x, y := ox+e.Px[0], oy+e.Px[1]
+			switch e.Identifier {
+			case LDtkZone:
+				rect := Rect{x - e.Pivot[0]*e.Width, y - e.Pivot[1]*e.Height, e.Width, e.Height}
+				z, zoneErr := fields.zone(name, rect, layer.GridSize, ox, oy)
+				err = errors.Join(err, zoneErr)
+				level.Zones = append(level.Zones, z)
+			case LDtkPoint:
+				level.Points = append(level.Points, FocusPoint{name, x, y})
+			case LDtkRail:
+				r := &Rail{Name: name}
+				var points []ldtkGridPoint
+				err = errors.Join(err, fields.get("path", &points), fields.get("closed", &r.Closed))
+				for _, p := range points {
+					r.Points = append(r.Points, gridCenter(p, layer.GridSize, ox, oy))
+				}
+				level.Rails = append(level.Rails, r)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("entity %s: %w", e.Iid, err)
+			}
+		}
+	}
+	return level, nil
+}
+
+// ldtkFields maps the field identifiers to the field values.
+type ldtkFields map[string]json.RawMessage
+
+// ldtkFieldMap returns the fields by identifier.
+func ldtkFieldMap(fields []ldtkFieldInst) ldtkFields {
+	m := make(ldtkFields, len(fields))
+	for _, f := range fields {
+		m[f.Identifier] = f.Value
+	}
+	return m
+}
+
+// get decodes the field value into v. Missing and null fields leave v unchanged.
+func (f ldtkFields) get(name string, v any) error {
+	raw, ok := f[name]
+	if !ok || len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	return nil
+}
+
+// zone returns the camera zone of the entity fields.
+func (f ldtkFields) zone(name string, rect Rect, gridSize, originX, originY float64) (*Zone, error) {
+	z := NewZone(name, rect)
+	var lock, smoothType string
+	var center *ldtkGridPoint
+	err := errors.Join(
+		f.get("priority", &z.Priority),
+		f.get("lock", &lock),
+		f.get("center", &center),
+		f.get("zoom", &z.Zoom),
+		f.get("smoothType", &smoothType),
+		f.get("blendIn", &z.BlendIn),
+		f.get("blendOut", &z.BlendOut),
+	)
+	if e := z.setLock(lock); e != nil {
+		err = errors.Join(err, fmt.Errorf("field \"lock\": %w", e))
+	}
+	if center != nil {
+		p := gridCenter(*center, gridSize, originX, originY)
+		z.CenterX, z.CenterY = p.X, p.Y
+	}
+	if smoothType != "" {
+		z.OverrideSmoothType = true
+		if e := z.SmoothType.UnmarshalText([]byte(smoothType)); e != nil {
+			err = errors.Join(err, fmt.Errorf("field \"smoothType\": %w", e))
+		}
+	}
+	return z, err
+}
+
+// gridCenter returns the world position of the center of the grid cell.
+func gridCenter(p ldtkGridPoint, gridSize, originX, originY float64) Point {
+	return Point{originX + (p.Cx+0.5)*gridSize, originY + (p.Cy+0.5)*gridSize}
+}
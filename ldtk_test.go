@@ -0,0 +1,144 @@
+package kamera_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+const testLDtk = `{
+ "jsonVersion": "1.5.3",
+ "worldLayout": "GridVania",
+ "externalLevels": false,
+ "levels": [
+  {
+   "identifier": "Level_0", "worldX": 0, "worldY": 0, "pxWid": 256, "pxHei": 128,
+   "layerInstances": [
+    {"__type": "IntGrid", "__gridSize": 16, "entityInstances": []},
+    {
+     "__type": "Entities", "__gridSize": 16, "__pxTotalOffsetX": 0, "__pxTotalOffsetY": 0,
+     "entityInstances": [
+      {
+       "__identifier": "CameraZone", "iid": "z1", "__pivot": [0.5, 1], "px": [64, 64], "width": 64, "height": 32,
+       "fieldInstances": [
+        {"__identifier": "name", "__type": "String", "__value": "boss"},
+        {"__identifier": "lock", "__type": "LocalEnum.Lock", "__value": "XY"},
+        {"__identifier": "zoom", "__type": "Float", "__value": 2},
+        {"__identifier": "center", "__type": "Point", "__value": {"cx": 4, "cy": 2}},
+        {"__identifier": "smoothType", "__type": "String", "__value": null}
+       ]
+      },
+      {"__identifier": "CameraPoint", "iid": "p1", "__pivot": [0.5, 0.5], "px": [100, 50], "width": 16, "height": 16, "fieldInstances": []},
+      {
+       "__identifier": "CameraRail", "iid": "r1", "__pivot": [0, 0], "px": [0, 0], "width": 16, "height": 16,
+       "fieldInstances": [{"__identifier": "path", "__type": "Array<Point>", "__value": [{"cx": 0, "cy": 0}, {"cx": 3, "cy": 0}]}]
+      }
+     ]
+    }
+   ]
+  },
+  {"identifier": "Level_1", "worldX": 256, "worldY": 0, "pxWid": 256, "pxHei": 128, "layerInstances": []}
+ ]
+}`
+
+func TestLoadLDtk(t *testing.T) {
+	fsys := fstest.MapFS{"world.ldtk": {Data: []byte(testLDtk)}}
+	world, err := kamera.LoadLDtk(fsys, "world.ldtk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(world.Levels) != 2 {
+		t.Fatal(len(world.Levels))
+	}
+	l0 := world.Level("Level_0")
+	if l0.Bounds != (kamera.Rect{X: 0, Y: 0, Width: 256, Height: 128}) || len(l0.Zones) != 1 {
+		t.Error(l0.Bounds, len(l0.Zones))
+	}
+	z := l0.Zone("boss")
+	if z == nil || !z.LockX || !z.LockY || z.Zoom != 2 || z.OverrideSmoothType ||
+		z.Rect != (kamera.Rect{X: 32, Y: 32, Width: 64, Height: 32}) || z.CenterX != 72 || z.CenterY != 40 {
+		t.Error(z)
+	}
+	if p, ok := l0.Point("p1"); !ok || p.X != 100 || p.Y != 50 {
+		t.Error(p)
+	}
+	if r := l0.Rail("r1"); r == nil || len(r.Points) != 2 || r.Points[1] != (kamera.Point{X: 56, Y: 8}) {
+		t.Error(r)
+	}
+	if l1 := world.Level("Level_1"); l1.Bounds.X != 256 {
+		t.Error(l1.Bounds)
+	}
+}
+
+func TestWorldUpdate(t *testing.T) {
+	fsys := fstest.MapFS{"world.ldtk": {Data: []byte(testLDtk)}}
+	world, err := kamera.LoadLDtk(fsys, "world.ldtk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes int
+	world.OnLevelChange = func(cam *kamera.Camera, from, to *kamera.Level) { changes++ }
+	cam := kamera.NewCamera(10, 10, 100, 100)
+
+	if !world.Update(cam, 10, 10) || world.Current().Name != "Level_0" || cam.Bounds.Width != 256 {
+		t.Error(world.Current())
+	}
+	if world.Update(cam, 20, 10) {
+		t.Error()
+	}
+	if !world.Update(cam, 300, 10) || world.Current().Name != "Level_1" || cam.Bounds.X != 256 || len(cam.Zones) != 0 {
+		t.Error(world.Current())
+	}
+	if changes != 2 {
+		t.Error(changes)
+	}
+	if err := world.SetLevel(cam, "Missing"); !errors.Is(err, kamera.ErrLevelNotFound) {
+		t.Error(err)
+	}
+}
+
+func TestLoadLDtkLinear(t *testing.T) {
+	for _, tc := range []struct {
+		layout string
+		x, y   float64
+	}{
+		{"LinearHorizontal", 150, 10},
+		{"LinearVertical", 10, 150},
+	} {
+		project := `{"worldLayout": "` + tc.layout + `", "levels": [
+ {"identifier": "A", "worldX": -1, "worldY": -1, "pxWid": 100, "pxHei": 100},
+ {"identifier": "B", "worldX": -1, "worldY": -1, "pxWid": 200, "pxHei": 200}
+]}`
+		world, err := kamera.LoadLDtk(fstest.MapFS{"w.ldtk": {Data: []byte(project)}}, "w.ldtk")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cam := kamera.NewCamera(0, 0, 100, 100)
+		if !world.Update(cam, 10, 10) || world.Current().Name != "A" {
+			t.Error(tc.layout, world.Current())
+		}
+		if !world.Update(cam, tc.x, tc.y) || world.Current().Name != "B" || cam.Bounds.X+cam.Bounds.Y != 100 {
+			t.Error(tc.layout, world.Current(), cam.Bounds)
+		}
+	}
+}
+
+func TestLoadLDtkWorlds(t *testing.T) {
+	const project = `{"worlds": [
+ {"identifier": "Overworld", "worldLayout": "Free", "levels": [{"identifier": "A", "worldX": 0, "worldY": 0, "pxWid": 100, "pxHei": 100}]},
+ {"identifier": "Dungeon", "worldLayout": "Free", "levels": [{"identifier": "B", "worldX": 0, "worldY": 0, "pxWid": 100, "pxHei": 100}]}
+]}`
+	fsys := fstest.MapFS{"w.ldtk": {Data: []byte(project)}}
+	if _, err := kamera.LoadLDtk(fsys, "w.ldtk"); !errors.Is(err, kamera.ErrMultipleWorlds) {
+		t.Error(err)
+	}
+	worlds, err := kamera.LoadLDtkWorlds(fsys, "w.ldtk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worlds) != 2 || worlds[0].Name != "Overworld" || worlds[1].Level("B") == nil || worlds[0].Level("B") != nil {
+		t.Error(worlds)
+	}
+}
@@ -0,0 +1,146 @@
+package kamera
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLevelNotFound is returned when a level does not exist in the world.
+var ErrLevelNotFound = errors.New("kamera: level not found")
+
+// FocusPoint is a named world-space point of interest, e.g. a camera target for a cutscene.
+type FocusPoint struct {
+	// Name is the point name.
+	Name string
+	// X and Y are the world position of the point.
+	X, Y float64
+}
+
+// Level is the camera data of a level loaded from a map editor file.
+type Level struct {
+	// Name is the level name.
+	Name string
+	// Bounds is the camera world bounds of the level. Empty rectangle means no limit.
+	Bounds Rect
+	// Zones are the camera zones of the level.
+	Zones []*Zone
+	// Rails are the camera rails of the level.
+	Rails []*Rail
+	// Points are the focus points of the level.
+	Points []FocusPoint
+}
+
+// Apply sets the bounds, zones and rail of the camera to the level data.
+//
+// The first rail of the level is used as Camera.Rail, nil if the level has no rails.
+func (l *Level) Apply(cam *Camera) {
+	cam.Bounds = l.Bounds
+	cam.Zones = l.Zones
+	cam.Rail = nil
+	if len(l.Rails) > 0 {
+		cam.Rail = l.Rails[0]
+	}
+}
+
+// Zone returns the zone with the given name, or nil if there is no such zone.
+func (l *Level) Zone(name string) *Zone {
+	for _, z := range l.Zones {
+		if z.Name == name {
+			return z
+		}
+	}
+	return nil
+}
+
+// Rail returns the rail with the given name, or nil if there is no such rail.
+func (l *Level) Rail(name string) *Rail {
+	for _, r := range l.Rails {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Point returns the focus point with the given name.
+func (l *Level) Point(name string) (FocusPoint, bool) {
+	for _, p := range l.Points {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return FocusPoint{}, false
+}
+
+// World is a set of levels. It reconfigures the camera when the target moves to another level.
+type World struct {
+	// Name is the world name. It is optional.
+	Name string
+	// Levels are the levels of the world. Level bounds are in world coordinates.
+	Levels []*Level
+	// OnLevelChange is called after a level is applied to the camera. It is optional.
+	//
+	// from is nil for the first level.
+	OnLevelChange func(cam *Camera, from, to *Level)
+
+	current *Level
+}
+
+// Level returns the level with the given name, or nil if there is no such level.
+func (w *World) Level(name string) *Level {
+	for _, l := range w.Levels {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Current returns the level applied to the camera, or nil if no level is applied yet.
+func (w *World) Current() *Level {
+	return w.current
+}
+
+// LevelAt returns the first level whose bounds contain the point, or nil if there is no such level.
+func (w *World) LevelAt(x, y float64) *Level {
+	for _, l := range w.Levels {
+		if l.Bounds.Contains(x, y) {
+			return l
+		}
+	}
+	return nil
+}
+
+// SetLevel applies the named level to the camera.
+func (w *World) SetLevel(cam *Camera, name string) error {
+	l := w.Level(name)
+	if l == nil {
+		return fmt.Errorf("%w: %q", ErrLevelNotFound, name)
+	}
+	w.setLevel(cam, l)
+	return nil
+}
+
+// Update applies the level that contains the target to the camera if the target has left the current level.
+//
+// Call it before LookAt() with the same target. It returns true if a new level was applied.
+func (w *World) Update(cam *Camera, targetX, targetY float64) bool {
+	if w.current != nil && w.current.Bounds.Contains(targetX, targetY) {
+		return false
+	}
+	l := w.LevelAt(targetX, targetY)
+	if l == nil || l == w.current {
+		return false
+	}
+	w.setLevel(cam, l)
+	return true
+}
+
+func (w *World) setLevel(cam *Camera, l *Level) {
+	from := w.current
+	w.current = l
+	l.Apply(cam)
+	if w.OnLevelChange != nil {
+		w.OnLevelChange(cam, from, l)
+	}
+}
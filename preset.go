@@ -0,0 +1,437 @@
+package kamera
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Preset is a named set of camera tuning parameters.
+//
+// Use RegisterPreset() to register it and Camera.ApplyPreset() to apply it.
+type Preset struct {
+	// Name is the preset name.
+	Name string
+	// SmoothType is the camera movement smoothing type.
+	SmoothType SmoothType
+	// If PerAxisSmoothType is true, SmoothTypeX and SmoothTypeY are used instead of SmoothType.
+	PerAxisSmoothType bool
+	// SmoothTypeX and SmoothTypeY are the per-axis smoothing types.
+	SmoothTypeX, SmoothTypeY SmoothType
+	// SmoothOptions holds the camera movement smoothing settings.
+	SmoothOptions *SmoothOptions
+	// ShakeEnabled enables the camera shake.
+	ShakeEnabled bool
+	// ShakeOptions holds the camera shake options.
+	ShakeOptions *ShakeOptions
+	// XAxisSmoothingDisabled disables the smoothing of the X axis if it's true.
+	XAxisSmoothingDisabled bool
+	// YAxisSmoothingDisabled disables the smoothing of the Y axis if it's true.
+	YAxisSmoothingDisabled bool
+	// DeadZoneX and DeadZoneY are the half-size of the dead zone. 0 means disabled.
+	DeadZoneX, DeadZoneY float64
+	// MinZoom and MaxZoom are the zoom limits. 0 means no limit.
+	MinZoom, MaxZoom float64
+}
+
+// presetFile is the preset file format.
+type presetFile struct {
+	Version uint16
+	Presets []*Preset
+}
+
+// presetBlend holds the state of a blend between two presets.
+type presetBlend struct {
+	from, to          presetParams
+	elapsed, duration float64
+}
+
+// presetParams holds the blendable parameters of a preset.
+type presetParams struct {
+	smooth               SmoothOptions
+	deadZoneX, deadZoneY float64
+	shakeMaxX, shakeMaxY float64
+	shakeMaxAngle        float64
+	shakeMaxZoomFactor   float64
+	shakeTimeScale       float64
+	shakeDecay           float64
+	shakeTraumaExponent  float64
+	hasShakeOptions      bool
+	hasSmoothOptions     bool
+}
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string]*Preset{}
+)
+
+func init() {
+	for _, p := range builtinPresets() {
+		RegisterPreset(p)
+	}
+}
+
+// builtinPresets returns the presets registered by default:
+// "platformer", "top-down", "racing" and "editor".
+func builtinPresets() []*Preset {
+	platformer := NewPreset("platformer")
+	platformer.SmoothType = SmoothDamp
+	platformer.SmoothOptions.SmoothDampTimeX = 0.15
+	platformer.SmoothOptions.SmoothDampTimeY = 0.3
+	platformer.ShakeEnabled = true
+	platformer.DeadZoneX = 40
+	platformer.DeadZoneY = 60
+	platformer.MinZoom, platformer.MaxZoom = 0.5, 2
+
+	topDown := NewPreset("top-down")
+	topDown.SmoothType = SmoothDamp
+	topDown.SmoothOptions.SmoothDampTimeX = 0.2
+	topDown.SmoothOptions.SmoothDampTimeY = 0.2
+	topDown.ShakeEnabled = true
+	topDown.DeadZoneX = 16
+	topDown.DeadZoneY = 16
+	topDown.MinZoom, topDown.MaxZoom = 0.5, 3
+
+	racing := NewPreset("racing")
+	racing.SmoothType = SmoothDamp
+	racing.SmoothOptions.SmoothDampTimeX = 0.08
+	racing.SmoothOptions.SmoothDampTimeY = 0.08
+	racing.SmoothOptions.SmoothDampMaxSpeedX = 4000
+	racing.SmoothOptions.SmoothDampMaxSpeedY = 4000
+	racing.ShakeEnabled = true
+	racing.ShakeOptions.MaxX = 4
+	racing.ShakeOptions.MaxY = 4
+	racing.ShakeOptions.MaxAngle = 0.01
+	racing.ShakeOptions.TimeScale = 20
+	racing.MinZoom, racing.MaxZoom = 0.25, 1.5
+
+	editor := NewPreset("editor")
+	editor.SmoothType = Lerp
+	editor.SmoothOptions.LerpSpeedX = 0.3
+	editor.SmoothOptions.LerpSpeedY = 0.3
+	editor.MinZoom, editor.MaxZoom = 0.05, 20
+
+	return []*Preset{platformer, topDown, racing, editor}
+}
+
+// NewPreset returns a new preset with the default camera settings.
+func NewPreset(name string) *Preset {
+	return &Preset{
+		Name:          name,
+		SmoothType:    None,
+		SmoothOptions: DefaultSmoothOptions(),
+		ShakeOptions:  DefaultCameraShakeOptions(),
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Fields missing in the data keep the default camera settings.
+func (p *Preset) UnmarshalJSON(data []byte) error {
+	type alias Preset
+	d := (*alias)(NewPreset(""))
+	if err := json.Unmarshal(data, d); err != nil {
+		return err
+	}
+	if d.SmoothOptions == nil {
+		d.SmoothOptions = DefaultSmoothOptions()
+	}
+	if d.ShakeOptions == nil {
+		d.ShakeOptions = DefaultCameraShakeOptions()
+	}
+	*p = Preset(*d)
+	return nil
+}
+
+// ParsePresets parses presets from JSON.
+//
+// The format is {"Presets": [{"Name": "boss", "SmoothType": "SmoothDamp", ...}]}
+// Version is optional.
+func ParsePresets(data []byte) ([]*Preset, error) {
+	f := presetFile{Version: SerialVersion}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if f.Version > SerialVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	for i, p := range f.Presets {
+		if p == nil || p.Name == "" {
+			return nil, fmt.Errorf("kamera: preset %d has no name", i)
+		}
+	}
+	return f.Presets, nil
+}
+
+// MarshalPresets returns the presets as JSON in the preset file format.
+func MarshalPresets(p ...*Preset) ([]byte, error) {
+	return json.MarshalIndent(presetFile{Version: SerialVersion, Presets: p}, "", "  ")
+}
+
+// LoadPresetFile parses a preset file and registers all presets in it.
+func LoadPresetFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	ps, err := ParsePresets(data)
+	if err != nil {
+		return fmt.Errorf("kamera: %s: %w", path, err)
+	}
+	for _, p := range ps {
+		RegisterPreset(p)
+	}
+	return nil
+}
+
+// RegisterPreset registers a copy of the preset by its name. A preset with the same name is replaced.
+func RegisterPreset(p *Preset) {
+	c := p.clone()
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+	presets[p.Name] = c
+}
+
+// LookupPreset returns a copy of the registered preset with the given name.
+//
+// Changing the copy does not change the registered preset. Use RegisterPreset() to replace it.
+func LookupPreset(name string) (*Preset, bool) {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	p, ok := presets[name]
+	if !ok {
+		return nil, false
+	}
+	return p.clone(), true
+}
+
+// PresetNames returns the sorted names of the registered presets.
+func PresetNames() []string {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Preset returns the current camera settings as a preset.
+func (cam *Camera) Preset(name string) *Preset {
+	p := &Preset{
+		Name:                   name,
+		SmoothType:             cam.SmoothType,
+		PerAxisSmoothType:      cam.PerAxisSmoothType,
+		SmoothTypeX:            cam.SmoothTypeX,
+		SmoothTypeY:            cam.SmoothTypeY,
+		ShakeEnabled:           cam.ShakeEnabled,
+		XAxisSmoothingDisabled: cam.XAxisSmoothingDisabled,
+		YAxisSmoothingDisabled: cam.YAxisSmoothingDisabled,
+		DeadZoneX:              cam.DeadZoneX,
+		DeadZoneY:              cam.DeadZoneY,
+		MinZoom:                cam.MinZoom,
+		MaxZoom:                cam.MaxZoom,
+	}
+	if cam.SmoothOptions != nil {
+		smooth := *cam.SmoothOptions
+		p.SmoothOptions = &smooth
+	}
+	if cam.ShakeOptions != nil {
+		p.ShakeOptions = cam.ShakeOptions.clone()
+	}
+	return p
+}
+
+// ApplyPreset applies the registered preset with the given name immediately.
+func (cam *Camera) ApplyPreset(name string) error {
+	return cam.BlendPreset(name, 0)
+}
+
+// BlendPreset applies the registered preset with the given name,
+// blending the numeric parameters from the current values over the duration in seconds.
+//
+// Smoothing type, axis flags, zoom limits and noise settings are switched immediately.
+func (cam *Camera) BlendPreset(name string, duration float64) error {
+	p, ok := LookupPreset(name)
+	if !ok {
+		return fmt.Errorf("kamera: unknown preset %q", name)
+	}
+	cam.SetPreset(p, duration)
+	return nil
+}
+
+// SetPreset applies the preset, blending the numeric parameters over the duration in seconds.
+//
+// The camera gets its own copies of the preset options. 0 duration applies the preset immediately.
+func (cam *Camera) SetPreset(p *Preset, duration float64) {
+	from := cam.presetParams()
+	cam.SmoothType = p.SmoothType
+	cam.PerAxisSmoothType = p.PerAxisSmoothType
+	cam.SmoothTypeX, cam.SmoothTypeY = p.SmoothTypeX, p.SmoothTypeY
+	cam.ShakeEnabled = p.ShakeEnabled
+	cam.XAxisSmoothingDisabled = p.XAxisSmoothingDisabled
+	cam.YAxisSmoothingDisabled = p.YAxisSmoothingDisabled
+	cam.MinZoom, cam.MaxZoom = p.MinZoom, p.MaxZoom
+	if p.SmoothOptions != nil {
+		smooth := *p.SmoothOptions
+		cam.SmoothOptions = &smooth
+	}
+	if p.ShakeOptions != nil {
+		cam.ShakeOptions = p.ShakeOptions.clone()
+	}
+	cam.DeadZoneX, cam.DeadZoneY = p.DeadZoneX, p.DeadZoneY
+	to := cam.presetParams()
+
+	if duration <= 0 || !from.hasSmoothOptions || !from.hasShakeOptions {
+		cam.presetBlend = nil
+		return
+	}
+	cam.presetBlend = &presetBlend{from: from, to: to, duration: duration}
+	cam.setPresetParams(from.lerp(to, 0))
+}
+
+// IsBlendingPreset returns true while a preset blend is in progress.
+func (cam *Camera) IsBlendingPreset() bool {
+	return cam.presetBlend != nil
+}
+
+// updatePresetBlend advances the preset blend by one frame.
+func (cam *Camera) updatePresetBlend() {
+	b := cam.presetBlend
+	if b == nil {
+		return
+	}
+	b.elapsed += cam.dt
+	if b.elapsed >= b.duration {
+		cam.setPresetParams(b.to)
+		cam.presetBlend = nil
+		return
+	}
+	t := b.elapsed / b.duration
+	t = t * t * (3 - 2*t) // smoothstep
+	cam.setPresetParams(b.from.lerp(b.to, t))
+}
+
+func (cam *Camera) presetParams() presetParams {
+	p := presetParams{deadZoneX: cam.DeadZoneX, deadZoneY: cam.DeadZoneY}
+	if cam.SmoothOptions != nil {
+		p.smooth = *cam.SmoothOptions
+		p.hasSmoothOptions = true
+	}
+	if so := cam.ShakeOptions; so != nil {
+		p.shakeMaxX, p.shakeMaxY = so.MaxX, so.MaxY
+		p.shakeMaxAngle, p.shakeMaxZoomFactor = so.MaxAngle, so.MaxZoomFactor
+		p.shakeTimeScale, p.shakeDecay = so.TimeScale, so.Decay
+		p.shakeTraumaExponent = so.TraumaExponent
+		p.hasShakeOptions = true
+	}
+	return p
+}
+
+// state returns the parameters for a CameraState.
+func (p presetParams) state() PresetBlendParams {
+	return PresetBlendParams{
+		SmoothOptions:       p.smooth,
+		DeadZoneX:           p.deadZoneX,
+		DeadZoneY:           p.deadZoneY,
+		ShakeMaxX:           p.shakeMaxX,
+		ShakeMaxY:           p.shakeMaxY,
+		ShakeMaxAngle:       p.shakeMaxAngle,
+		ShakeMaxZoomFactor:  p.shakeMaxZoomFactor,
+		ShakeTimeScale:      p.shakeTimeScale,
+		ShakeDecay:          p.shakeDecay,
+		ShakeTraumaExponent: p.shakeTraumaExponent,
+	}
+}
+
+// params returns the parameters of a preset blend restored from a CameraState.
+func (p PresetBlendParams) params() presetParams {
+	return presetParams{
+		smooth:              p.SmoothOptions,
+		deadZoneX:           p.DeadZoneX,
+		deadZoneY:           p.DeadZoneY,
+		shakeMaxX:           p.ShakeMaxX,
+		shakeMaxY:           p.ShakeMaxY,
+		shakeMaxAngle:       p.ShakeMaxAngle,
+		shakeMaxZoomFactor:  p.ShakeMaxZoomFactor,
+		shakeTimeScale:      p.ShakeTimeScale,
+		shakeDecay:          p.ShakeDecay,
+		shakeTraumaExponent: p.ShakeTraumaExponent,
+		hasShakeOptions:     true,
+		hasSmoothOptions:    true,
+	}
+}
+
+func (cam *Camera) setPresetParams(p presetParams) {
+	cam.DeadZoneX, cam.DeadZoneY = p.deadZoneX, p.deadZoneY
+	*cam.SmoothOptions = p.smooth
+	so := cam.ShakeOptions
+	so.MaxX, so.MaxY = p.shakeMaxX, p.shakeMaxY
+	so.MaxAngle, so.MaxZoomFactor = p.shakeMaxAngle, p.shakeMaxZoomFactor
+	so.TimeScale, so.Decay = p.shakeTimeScale, p.shakeDecay
+	so.TraumaExponent = p.shakeTraumaExponent
+}
+
+func (a presetParams) lerp(b presetParams, t float64) presetParams {
+	smooth := b.smooth // non-numeric fields are switched immediately
+	smooth.LerpSpeedX = lerp(a.smooth.LerpSpeedX, b.smooth.LerpSpeedX, t)
+	smooth.LerpSpeedY = lerp(a.smooth.LerpSpeedY, b.smooth.LerpSpeedY, t)
+	smooth.SmoothDampTimeX = lerp(a.smooth.SmoothDampTimeX, b.smooth.SmoothDampTimeX, t)
+	smooth.SmoothDampTimeY = lerp(a.smooth.SmoothDampTimeY, b.smooth.SmoothDampTimeY, t)
+	smooth.SmoothDampMaxSpeedX = lerp(a.smooth.SmoothDampMaxSpeedX, b.smooth.SmoothDampMaxSpeedX, t)
+	smooth.SmoothDampMaxSpeedY = lerp(a.smooth.SmoothDampMaxSpeedY, b.smooth.SmoothDampMaxSpeedY, t)
+	smooth.LerpSpeedNegX = lerpDirectional(a.smooth.LerpSpeedX, a.smooth.LerpSpeedNegX, b.smooth.LerpSpeedX, b.smooth.LerpSpeedNegX, t)
+	smooth.LerpSpeedNegY = lerpDirectional(a.smooth.LerpSpeedY, a.smooth.LerpSpeedNegY, b.smooth.LerpSpeedY, b.smooth.LerpSpeedNegY, t)
+	smooth.SmoothDampTimeNegX = lerpDirectional(a.smooth.SmoothDampTimeX, a.smooth.SmoothDampTimeNegX, b.smooth.SmoothDampTimeX, b.smooth.SmoothDampTimeNegX, t)
+	smooth.SmoothDampTimeNegY = lerpDirectional(a.smooth.SmoothDampTimeY, a.smooth.SmoothDampTimeNegY, b.smooth.SmoothDampTimeY, b.smooth.SmoothDampTimeNegY, t)
+	smooth.SmoothDampMaxSpeedNegX = lerpDirectional(a.smooth.SmoothDampMaxSpeedX, a.smooth.SmoothDampMaxSpeedNegX, b.smooth.SmoothDampMaxSpeedX, b.smooth.SmoothDampMaxSpeedNegX, t)
+	smooth.SmoothDampMaxSpeedNegY = lerpDirectional(a.smooth.SmoothDampMaxSpeedY, a.smooth.SmoothDampMaxSpeedNegY, b.smooth.SmoothDampMaxSpeedY, b.smooth.SmoothDampMaxSpeedNegY, t)
+	smooth.SpringFrequencyX = lerp(a.smooth.SpringFrequencyX, b.smooth.SpringFrequencyX, t)
+	smooth.SpringFrequencyY = lerp(a.smooth.SpringFrequencyY, b.smooth.SpringFrequencyY, t)
+	smooth.SpringDampingX = lerp(a.smooth.SpringDampingX, b.smooth.SpringDampingX, t)
+	smooth.SpringDampingY = lerp(a.smooth.SpringDampingY, b.smooth.SpringDampingY, t)
+	smooth.SpringResponseX = lerp(a.smooth.SpringResponseX, b.smooth.SpringResponseX, t)
+	smooth.SpringResponseY = lerp(a.smooth.SpringResponseY, b.smooth.SpringResponseY, t)
+	a.smooth = smooth
+	a.deadZoneX = lerp(a.deadZoneX, b.deadZoneX, t)
+	a.deadZoneY = lerp(a.deadZoneY, b.deadZoneY, t)
+	a.shakeMaxX = lerp(a.shakeMaxX, b.shakeMaxX, t)
+	a.shakeMaxY = lerp(a.shakeMaxY, b.shakeMaxY, t)
+	a.shakeMaxAngle = lerp(a.shakeMaxAngle, b.shakeMaxAngle, t)
+	a.shakeMaxZoomFactor = lerp(a.shakeMaxZoomFactor, b.shakeMaxZoomFactor, t)
+	a.shakeTimeScale = lerp(a.shakeTimeScale, b.shakeTimeScale, t)
+	a.shakeDecay = lerp(a.shakeDecay, b.shakeDecay, t)
+	a.shakeTraumaExponent = lerp(a.shakeTraumaExponent, b.shakeTraumaExponent, t)
+	return a
+}
+
+// lerpDirectional interpolates negative-direction parameters where 0 means the positive-direction value.
+func lerpDirectional(aPos, aNeg, bPos, bNeg, t float64) float64 {
+	return lerp(directional(aPos, aNeg, -1), directional(bPos, bNeg, -1), t)
+}
+
+// clone returns a copy of the preset with its own options.
+func (p *Preset) clone() *Preset {
+	c := *p
+	if p.SmoothOptions != nil {
+		smooth := *p.SmoothOptions
+		c.SmoothOptions = &smooth
+	}
+	if p.ShakeOptions != nil {
+		c.ShakeOptions = p.ShakeOptions.clone()
+	}
+	return &c
+}
+
+// clone returns a copy of the options with its own noise state.
+func (so *ShakeOptions) clone() *ShakeOptions {
+	c := *so
+	if so.Noise != nil {
+		noise := *so.Noise
+		c.Noise = &noise
+	}
+	return &c
+}
@@ -0,0 +1,80 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestApplyPreset(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	if err := k.ApplyPreset("platformer"); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := kamera.LookupPreset("platformer")
+	if k.SmoothType != kamera.SmoothDamp || k.DeadZoneX != 40 || k.SmoothOptions == p.SmoothOptions {
+		t.Error()
+	}
+	if err := k.ApplyPreset("unknown"); err == nil {
+		t.Error()
+	}
+}
+
+func TestPresetCopies(t *testing.T) {
+	p := kamera.NewPreset("copy")
+	p.DeadZoneX = 5
+	kamera.RegisterPreset(p)
+	p.DeadZoneX = 10
+	q, _ := kamera.LookupPreset("copy")
+	q.SmoothOptions.LerpSpeedX = 0.9
+	r, _ := kamera.LookupPreset("copy")
+	if r.DeadZoneX != 5 || r.SmoothOptions.LerpSpeedX != 0.09 || r.ShakeOptions.Noise == q.ShakeOptions.Noise {
+		t.Error(r.DeadZoneX, r.SmoothOptions.LerpSpeedX)
+	}
+}
+
+func TestBlendPreset(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ApplyPreset("top-down")
+	k.BlendPreset("platformer", 0.5)
+	k.LookAt(0, 0)
+	if !k.IsBlendingPreset() || k.DeadZoneX <= 16 || k.DeadZoneX >= 40 {
+		t.Error(k.DeadZoneX)
+	}
+	for range 30 {
+		k.LookAt(0, 0)
+	}
+	if k.IsBlendingPreset() || k.DeadZoneX != 40 || k.SmoothOptions.SmoothDampTimeY != 0.3 {
+		t.Error()
+	}
+}
+
+func TestParsePresets(t *testing.T) {
+	ps, err := kamera.ParsePresets([]byte(`{"Presets":[{"Name":"boss","SmoothType":"Lerp","SmoothOptions":{"LerpSpeedX":0.5},"MaxZoom":2}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps[0].SmoothType != kamera.Lerp || ps[0].SmoothOptions.LerpSpeedX != 0.5 || ps[0].SmoothOptions.LerpSpeedY != 0.09 || ps[0].MaxZoom != 2 {
+		t.Error()
+	}
+	data, _ := kamera.MarshalPresets(ps...)
+	if ps2, err := kamera.ParsePresets(data); err != nil || *ps2[0].SmoothOptions != *ps[0].SmoothOptions {
+		t.Error(err)
+	}
+	if _, err := kamera.ParsePresets([]byte(`{"Presets":[{}]}`)); err == nil {
+		t.Error()
+	}
+}
+
+func TestDeadZone(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.DeadZoneX = 10
+	k.LookAt(5, 0)
+	if k.CenterX() != 0 {
+		t.Error()
+	}
+	k.LookAt(15, 0)
+	if k.CenterX() != 5 {
+		t.Error()
+	}
+}
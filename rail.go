@@ -0,0 +1,51 @@
+package kamera
+
+// Rail is a world-space polyline path the camera center is constrained to.
+//
+// Set Camera.Rail to move the camera along the rail. LookAt() moves the camera to the closest point of the rail to the target.
+type Rail struct {
+	// Name is the rail name. It is optional.
+	Name string
+	// Points are the polyline vertices.
+	Points []Point
+	// If Closed is true, the last point is connected to the first point.
+	Closed bool
+}
+
+// Closest returns the closest point of the rail to the given point.
+//
+// If the rail has no points, the given point is returned.
+func (r *Rail) Closest(x, y float64) (float64, float64) {
+	n := len(r.Points)
+	switch n {
+	case 0:
+		return x, y
+	case 1:
+		return r.Points[0].X, r.Points[0].Y
+	}
+	segments := n - 1
+	if r.Closed {
+		segments = n
+	}
+	bestX, bestY := r.Points[0].X, r.Points[0].Y
+	bestDistSq := (x-bestX)*(x-bestX) + (y-bestY)*(y-bestY)
+	for i := range segments {
+		a, b := r.Points[i], r.Points[(i+1)%n]
+		px, py := closestOnSegment(x, y, a, b)
+		if d := (x-px)*(x-px) + (y-py)*(y-py); d < bestDistSq {
+			bestX, bestY, bestDistSq = px, py, d
+		}
+	}
+	return bestX, bestY
+}
+
+// closestOnSegment returns the closest point of the segment ab to the point.
+func closestOnSegment(x, y float64, a, b Point) (float64, float64) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lenSq := dx*dx + dy*dy
+	if lenSq == 0 {
+		return a.X, a.Y
+	}
+	t := min(max(((x-a.X)*dx+(y-a.Y)*dy)/lenSq, 0), 1)
+	return a.X + dx*t, a.Y + dy*t
+}
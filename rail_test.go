@@ -0,0 +1,17 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestRailClosest(t *testing.T) {
+	r := &kamera.Rail{Points: []kamera.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}}}
+	if x, y := r.Closest(50, 20); x != 50 || y != 0 {
+		t.Error(x, y)
+	}
+	if x, y := r.Closest(150, 60); x != 100 || y != 60 {
+		t.Error(x, y)
+	}
+}
@@ -0,0 +1,60 @@
+package kamera
+
+// Rect is a world-space rectangle.
+type Rect struct {
+	// Top-left X position of the rectangle
+	X float64
+	// Top-left Y position of the rectangle
+	Y float64
+	// Width is the rectangle width
+	Width float64
+	// Height is the rectangle height
+	Height float64
+}
+
+// Right returns the right edge position of the rectangle.
+func (r Rect) Right() float64 {
+	return r.X + r.Width
+}
+
+// Bottom returns the bottom edge position of the rectangle.
+func (r Rect) Bottom() float64 {
+	return r.Y + r.Height
+}
+
+// Center returns the center point of the rectangle.
+func (r Rect) Center() (x, y float64) {
+	return r.X + r.Width*0.5, r.Y + r.Height*0.5
+}
+
+// Empty returns true if the rectangle has no area.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains returns true if the point is inside the rectangle.
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.X && x < r.Right() && y >= r.Y && y < r.Bottom()
+}
+
+// clampAxis clamps the center of a span with the given half-size into [lo, hi].
+//
+// If the span is larger than [lo, hi], the center of [lo, hi] is returned.
+func clampAxis(center, halfSize, lo, hi float64) float64 {
+	return clampSpan(center, halfSize, halfSize, lo, hi)
+}
+
+// clampSpan clamps the position of a span [pos-before, pos+after] into [lo, hi].
+//
+// If the span is larger than [lo, hi], the span is centered in [lo, hi].
+func clampSpan(pos, before, after, lo, hi float64) float64 {
+	if hi-lo <= before+after {
+		return (lo+hi)*0.5 + (before-after)*0.5
+	}
+	return min(max(pos, lo+before), hi-after)
+}
+
+// Point is a world-space point.
+type Point struct {
+	X, Y float64
+}
@@ -0,0 +1,130 @@
+package kamera
+
+// RoomManager clamps the camera to the current room and scrolls to the neighbouring room
+// when the target crosses into it (Zelda/Metroid style).
+//
+// Call Update() instead of Camera.LookAt(), or UpdateDt() instead of Camera.LookAtDt().
+// Pause the gameplay while InTransition() is true.
+type RoomManager struct {
+	// Rooms are the world-space room rectangles.
+	Rooms []Rect
+	// Duration is the transition duration in seconds. Default is 0.5
+	Duration float64
+	// Easing is the transition easing. Default is EaseSmoothstep
+	Easing Easing
+	// OnRoomChange is called when a transition starts. It is optional.
+	//
+	// from is -1 for the first room.
+	OnRoomChange func(cam *Camera, from, to int)
+	// OnTransitionEnd is called when a transition ends. It is optional.
+	OnTransitionEnd func(cam *Camera, room int)
+
+	current      int
+	transition   bool
+	elapsed      float64
+	fromX, fromY float64
+	toX, toY     float64
+}
+
+// NewRoomManager returns a new room manager with default transition settings.
+func NewRoomManager(rooms ...Rect) *RoomManager {
+	return &RoomManager{
+		Rooms:    rooms,
+		Duration: 0.5,
+		Easing:   EaseSmoothstep,
+		current:  -1,
+	}
+}
+
+// Current returns the index of the current room, -1 if there is no current room yet.
+//
+// During a transition it is the new room.
+func (m *RoomManager) Current() int {
+	return m.current
+}
+
+// InTransition returns true while a room transition is in progress.
+func (m *RoomManager) InTransition() bool {
+	return m.transition
+}
+
+// Progress returns the linear progress of the transition in the range [0-1]. It is 1 if there is no transition.
+func (m *RoomManager) Progress() float64 {
+	if !m.transition || m.Duration <= 0 {
+		return 1
+	}
+	return min(m.elapsed/m.Duration, 1)
+}
+
+// RoomAt returns the index of the first room that contains the point, -1 if there is no such room.
+func (m *RoomManager) RoomAt(x, y float64) int {
+	for i, r := range m.Rooms {
+		if r.Contains(x, y) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Update advances the transition or detects a room change and aligns the camera to the target.
+//
+// The first room is applied without a transition. If the target is outside all rooms, the camera stays in the current room.
+// During a transition the camera is moved without the LookAt() pipeline, so its smoothing state is kept.
+func (m *RoomManager) Update(cam *Camera, targetX, targetY float64) {
+	m.UpdateDt(cam, targetX, targetY, deltaTime)
+}
+
+// UpdateDt is like Update() with a custom time step dt in seconds. See Camera.LookAtDt().
+func (m *RoomManager) UpdateDt(cam *Camera, targetX, targetY, dt float64) {
+	if dt <= 0 {
+		dt = deltaTime
+	}
+	if m.transition {
+		m.elapsed += dt
+		if m.elapsed < m.Duration {
+			t := ease(m.Easing, m.elapsed/m.Duration)
+			v := cam.Transform()
+			v.X, v.Y = lerp(m.fromX, m.toX, t), lerp(m.fromY, m.toY, t)
+			cam.setView(v, dt)
+			return
+		}
+		m.transition = false
+		cam.Bounds = m.Rooms[m.current]
+		cam.SetCenter(m.toX, m.toY)
+		if m.OnTransitionEnd != nil {
+			m.OnTransitionEnd(cam, m.current)
+		}
+	}
+
+	if m.current < 0 || m.current >= len(m.Rooms) || !m.Rooms[m.current].Contains(targetX, targetY) {
+		if room := m.RoomAt(targetX, targetY); room >= 0 {
+			m.enter(cam, room, targetX, targetY)
+			if m.transition {
+				return
+			}
+		}
+	}
+	cam.LookAtDt(targetX, targetY, dt)
+}
+
+// enter starts the transition to the room.
+func (m *RoomManager) enter(cam *Camera, room int, targetX, targetY float64) {
+	from := m.current
+	m.current = room
+	if m.OnRoomChange != nil {
+		m.OnRoomChange(cam, from, room)
+	}
+	if from < 0 || m.Duration <= 0 {
+		cam.Bounds = m.Rooms[room]
+		if from >= 0 {
+			cam.SetCenter(cam.clampToBounds(targetX, targetY, cam.Bounds))
+		}
+		return
+	}
+	m.transition = true
+	m.elapsed = 0
+	m.fromX, m.fromY = cam.TempTargetX, cam.TempTargetY
+	m.toX, m.toY = cam.clampToBounds(targetX, targetY, m.Rooms[room])
+	// the bounds are applied at the end of the transition
+	cam.Bounds = Rect{}
+}
@@ -0,0 +1,66 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestRoomManager(t *testing.T) {
+	cam := kamera.NewCamera(50, 50, 100, 100)
+	rooms := kamera.NewRoomManager(
+		kamera.Rect{X: 0, Y: 0, Width: 200, Height: 100},
+		kamera.Rect{X: 200, Y: 0, Width: 200, Height: 100},
+	)
+	var changes, ends int
+	rooms.OnRoomChange = func(cam *kamera.Camera, from, to int) { changes++ }
+	rooms.OnTransitionEnd = func(cam *kamera.Camera, room int) { ends++ }
+
+	rooms.Update(cam, 190, 50)
+	if rooms.Current() != 0 || rooms.InTransition() || cam.CenterX() != 150 {
+		t.Error(rooms.Current(), cam.CenterX())
+	}
+	rooms.Update(cam, 210, 50)
+	if rooms.Current() != 1 || !rooms.InTransition() || changes != 2 {
+		t.Error(rooms.Current(), changes)
+	}
+	for range 15 {
+		rooms.Update(cam, 210, 50)
+	}
+	if x := cam.CenterX(); x <= 150 || x >= 250 || !rooms.InTransition() {
+		t.Error(x)
+	}
+	for range 30 {
+		rooms.Update(cam, 210, 50)
+	}
+	if rooms.InTransition() || cam.CenterX() != 250 || ends != 1 || cam.Bounds.X != 200 {
+		t.Error(cam.CenterX(), ends)
+	}
+}
+
+func TestRoomManagerUpdateDt(t *testing.T) {
+	cam := kamera.NewCamera(50, 50, 100, 100)
+	cam.SmoothType = kamera.Lerp
+	rooms := kamera.NewRoomManager(
+		kamera.Rect{X: 0, Y: 0, Width: 200, Height: 100},
+		kamera.Rect{X: 200, Y: 0, Width: 200, Height: 100},
+	)
+	rooms.Update(cam, 50, 50)
+	rooms.Update(cam, 210, 50)
+	// 0.25 seconds of a 0.5 second transition in 5 steps
+	for range 5 {
+		rooms.UpdateDt(cam, 210, 50, 0.05)
+	}
+	if p := rooms.Progress(); !rooms.InTransition() || p < 0.49 || p > 0.51 {
+		t.Error(p)
+	}
+	if x := cam.CenterX(); x <= 50 || x >= 250 || cam.CurrentVelocityX != 0 {
+		t.Error(x)
+	}
+	for range 6 {
+		rooms.UpdateDt(cam, 210, 50, 0.05)
+	}
+	if rooms.InTransition() || cam.CenterX() != 250 {
+		t.Error(cam.CenterX())
+	}
+}
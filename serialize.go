@@ -0,0 +1,960 @@
+package kamera
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/setanarut/fastnoise"
+)
+
+// SerialVersion is the current version of the JSON and binary serialization formats.
+//
+// Data written by older versions can still be loaded.
+const SerialVersion uint16 = 16
+
+var (
+	// ErrUnsupportedVersion is returned when the data was written by a newer version.
+	ErrUnsupportedVersion = errors.New("kamera: unsupported serialization version")
+	// ErrShortData is returned when the binary data is truncated.
+	ErrShortData = errors.New("kamera: binary data is too short")
+
+	errNoiseTypes = errors.New("kamera: cannot read the noise and fractal types of fastnoise.NoiseState")
+)
+
+// String returns the name of the smoothing type.
+func (t SmoothType) String() string {
+	switch t {
+	case None:
+		return "None"
+	case Lerp:
+		return "Lerp"
+	case SmoothDamp:
+		return "SmoothDamp"
+	case Spring:
+		return "Spring"
+	}
+	return fmt.Sprintf("SmoothType(%d)", int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t SmoothType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *SmoothType) UnmarshalText(text []byte) error {
+	for st := None; st <= Spring; st++ {
+		if st.String() == string(text) {
+			*t = st
+			return nil
+		}
+	}
+	return fmt.Errorf("kamera: unknown smooth type %q", text)
+}
+
+// String returns the name of the decay type.
+func (t DecayType) String() string {
+	switch t {
+	case LinearDecay:
+		return "LinearDecay"
+	case ExponentialDecay:
+		return "ExponentialDecay"
+	}
+	return fmt.Sprintf("DecayType(%d)", int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DecayType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *DecayType) UnmarshalText(text []byte) error {
+	for dt := LinearDecay; dt <= ExponentialDecay; dt++ {
+		if dt.String() == string(text) {
+			*t = dt
+			return nil
+		}
+	}
+	return fmt.Errorf("kamera: unknown decay type %q", text)
+}
+
+// String returns the name of the pivot mode.
+func (m PivotMode) String() string {
+	switch m {
+	case PivotAnchor:
+		return "PivotAnchor"
+	case PivotScreen:
+		return "PivotScreen"
+	case PivotWorld:
+		return "PivotWorld"
+	}
+	return fmt.Sprintf("PivotMode(%d)", int(m))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m PivotMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *PivotMode) UnmarshalText(text []byte) error {
+	for pm := PivotAnchor; pm <= PivotWorld; pm++ {
+		if pm.String() == string(text) {
+			*m = pm
+			return nil
+		}
+	}
+	return fmt.Errorf("kamera: unknown pivot mode %q", text)
+}
+
+// noiseData is the serialized form of the fastnoise settings.
+type noiseData struct {
+	Seed                 int
+	Frequency            float64
+	NoiseType            fastnoise.NoiseType
+	RotationType3D       fastnoise.RotationType3D
+	FractalType          fastnoise.FractalType
+	Octaves              int
+	Lacunarity           float64
+	Gain                 float64
+	WeightedStrength     float64
+	PingPongStrength     float64
+	CellularDistanceFunc fastnoise.CellularDistanceFunc
+	CellularReturnType   fastnoise.CellularReturnType
+	CellularJitterMod    float64
+	DomainWarpType       fastnoise.DomainWarpType
+	DomainWarpAmp        float64
+}
+
+// shakeOptionsData is the serialized form of ShakeOptions.
+//
+// TraumaCurve is a function and is not serialized.
+type shakeOptionsData struct {
+	Version        uint16
+	Noise          noiseData
+	MaxX           float64
+	MaxY           float64
+	MaxAngle       float64
+	MaxZoomFactor  float64
+	TimeScale      float64
+	Decay          float64
+	DecayType      DecayType
+	TraumaExponent float64
+}
+
+// cameraData is the serialized form of Camera.
+type cameraData struct {
+	Version                uint16
+	Width, Height          float64
+	SmoothType             SmoothType
+	ShakeEnabled           bool
+	XAxisSmoothingDisabled bool
+	YAxisSmoothingDisabled bool
+	DeadZoneX, DeadZoneY   float64    // since version 2
+	MinZoom, MaxZoom       float64    // since version 2
+	Bounds                 Rect       // since version 3
+	PerAxisSmoothType      bool       // since version 6
+	SmoothTypeX            SmoothType // since version 6
+	SmoothTypeY            SmoothType // since version 6
+	GroundLock             bool       // since version 8
+	GroundLockBandUp       float64    // since version 8
+	GroundLockBandDown     float64    // since version 8
+	HardLimit              Rect       // since version 9
+	AnchorX, AnchorY       float64    // since version 10
+	AnchorLerpSpeed        float64    // since version 10
+	PivotMode              PivotMode  // since version 11
+	PivotX, PivotY         float64    // since version 11
+	State                  CameraState
+	SmoothOptions          *SmoothOptions
+	ShakeOptions           *ShakeOptions
+}
+
+// noiseTypes returns the noise and fractal types of the noise state.
+//
+// fastnoise has setters but no getters for them, so they are read with reflection.
+// An error is returned if the fields are not found, e.g. after they are renamed in fastnoise.
+func noiseTypes(n *fastnoise.NoiseState[float64]) (fastnoise.NoiseType, fastnoise.FractalType, error) {
+	v := reflect.ValueOf(n).Elem()
+	noiseType, fractalType := v.FieldByName("noiseType"), v.FieldByName("fractalType")
+	if !noiseType.IsValid() || !noiseType.CanInt() || !fractalType.IsValid() || !fractalType.CanInt() {
+		return 0, 0, errNoiseTypes
+	}
+	return fastnoise.NoiseType(noiseType.Int()), fastnoise.FractalType(fractalType.Int()), nil
+}
+
+func (so *ShakeOptions) data() (shakeOptionsData, error) {
+	n := so.Noise
+	if n == nil {
+		n = fastnoise.NewNoiseState[float64]()
+	}
+	noiseType, fractalType, err := noiseTypes(n)
+	if err != nil {
+		return shakeOptionsData{}, err
+	}
+	return shakeOptionsData{
+		Version: SerialVersion,
+		Noise: noiseData{
+			Seed:                 n.Seed,
+			Frequency:            n.Frequency,
+			NoiseType:            noiseType,
+			RotationType3D:       n.RotationType3D,
+			FractalType:          fractalType,
+			Octaves:              n.Octaves,
+			Lacunarity:           n.Lacunarity,
+			Gain:                 n.Gain,
+			WeightedStrength:     n.WeightedStrength,
+			PingPongStrength:     n.PingPongStrength,
+			CellularDistanceFunc: n.CellularDistanceFunc,
+			CellularReturnType:   n.CellularReturnType,
+			CellularJitterMod:    n.CellularJitterMod,
+			DomainWarpType:       n.DomainWarpType,
+			DomainWarpAmp:        n.DomainWarpAmp,
+		},
+		MaxX:           so.MaxX,
+		MaxY:           so.MaxY,
+		MaxAngle:       so.MaxAngle,
+		MaxZoomFactor:  so.MaxZoomFactor,
+		TimeScale:      so.TimeScale,
+		Decay:          so.Decay,
+		DecayType:      so.DecayType,
+		TraumaExponent: so.TraumaExponent,
+	}, nil
+}
+
+func (so *ShakeOptions) setData(d shakeOptionsData) error {
+	if d.Version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	if so.Noise == nil {
+		so.Noise = fastnoise.NewNoiseState[float64]()
+	}
+	n := so.Noise
+	n.Seed = d.Noise.Seed
+	n.Frequency = d.Noise.Frequency
+	n.RotationType3D = d.Noise.RotationType3D
+	n.Octaves = d.Noise.Octaves
+	n.Lacunarity = d.Noise.Lacunarity
+	n.Gain = d.Noise.Gain
+	n.WeightedStrength = d.Noise.WeightedStrength
+	n.PingPongStrength = d.Noise.PingPongStrength
+	n.CellularDistanceFunc = d.Noise.CellularDistanceFunc
+	n.CellularReturnType = d.Noise.CellularReturnType
+	n.CellularJitterMod = d.Noise.CellularJitterMod
+	n.DomainWarpType = d.Noise.DomainWarpType
+	n.DomainWarpAmp = d.Noise.DomainWarpAmp
+	n.SetNoiseType(d.Noise.NoiseType)
+	n.SetFractalType(d.Noise.FractalType)
+	so.MaxX = d.MaxX
+	so.MaxY = d.MaxY
+	so.MaxAngle = d.MaxAngle
+	so.MaxZoomFactor = d.MaxZoomFactor
+	so.TimeScale = d.TimeScale
+	so.Decay = d.Decay
+	so.DecayType = d.DecayType
+	so.TraumaExponent = d.TraumaExponent
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// TraumaCurve is not serialized.
+func (so *ShakeOptions) MarshalJSON() ([]byte, error) {
+	d, err := so.data()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Fields missing in the data keep their current values.
+func (so *ShakeOptions) UnmarshalJSON(data []byte) error {
+	d, err := so.data()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	return so.setData(d)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is JSON.
+func (so *ShakeOptions) MarshalText() ([]byte, error) {
+	return so.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (so *ShakeOptions) UnmarshalText(text []byte) error {
+	return so.UnmarshalJSON(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (so *ShakeOptions) MarshalBinary() ([]byte, error) {
+	w := &binWriter{}
+	w.u16(SerialVersion)
+	if err := so.appendBinary(w); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (so *ShakeOptions) UnmarshalBinary(data []byte) error {
+	r := &binReader{buf: data}
+	version := r.u16()
+	if version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	d, err := so.data()
+	if err != nil {
+		return err
+	}
+	d.readBinary(r, version)
+	if r.err != nil {
+		return r.err
+	}
+	return so.setData(d)
+}
+
+func (so *ShakeOptions) appendBinary(w *binWriter) error {
+	d, err := so.data()
+	if err != nil {
+		return err
+	}
+	w.i64(int64(d.Noise.Seed))
+	w.f64(d.Noise.Frequency)
+	w.i64(int64(d.Noise.NoiseType))
+	w.i64(int64(d.Noise.RotationType3D))
+	w.i64(int64(d.Noise.FractalType))
+	w.i64(int64(d.Noise.Octaves))
+	w.f64(d.Noise.Lacunarity)
+	w.f64(d.Noise.Gain)
+	w.f64(d.Noise.WeightedStrength)
+	w.f64(d.Noise.PingPongStrength)
+	w.i64(int64(d.Noise.CellularDistanceFunc))
+	w.i64(int64(d.Noise.CellularReturnType))
+	w.f64(d.Noise.CellularJitterMod)
+	w.i64(int64(d.Noise.DomainWarpType))
+	w.f64(d.Noise.DomainWarpAmp)
+	w.f64(d.MaxX)
+	w.f64(d.MaxY)
+	w.f64(d.MaxAngle)
+	w.f64(d.MaxZoomFactor)
+	w.f64(d.TimeScale)
+	w.f64(d.Decay)
+	w.i64(int64(d.DecayType))
+	w.f64(d.TraumaExponent)
+	return nil
+}
+
+func (d *shakeOptionsData) readBinary(r *binReader, version uint16) {
+	d.Version = version
+	d.Noise.Seed = int(r.i64())
+	d.Noise.Frequency = r.f64()
+	d.Noise.NoiseType = fastnoise.NoiseType(r.i64())
+	d.Noise.RotationType3D = fastnoise.RotationType3D(r.i64())
+	d.Noise.FractalType = fastnoise.FractalType(r.i64())
+	d.Noise.Octaves = int(r.i64())
+	d.Noise.Lacunarity = r.f64()
+	d.Noise.Gain = r.f64()
+	d.Noise.WeightedStrength = r.f64()
+	d.Noise.PingPongStrength = r.f64()
+	d.Noise.CellularDistanceFunc = fastnoise.CellularDistanceFunc(r.i64())
+	d.Noise.CellularReturnType = fastnoise.CellularReturnType(r.i64())
+	d.Noise.CellularJitterMod = r.f64()
+	d.Noise.DomainWarpType = fastnoise.DomainWarpType(r.i64())
+	d.Noise.DomainWarpAmp = r.f64()
+	d.MaxX = r.f64()
+	d.MaxY = r.f64()
+	d.MaxAngle = r.f64()
+	d.MaxZoomFactor = r.f64()
+	d.TimeScale = r.f64()
+	d.Decay = r.f64()
+	d.DecayType = DecayType(r.i64())
+	d.TraumaExponent = r.f64()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (so *SmoothOptions) MarshalJSON() ([]byte, error) {
+	type alias SmoothOptions
+	return json.Marshal(struct {
+		Version uint16
+		*alias
+	}{SerialVersion, (*alias)(so)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Fields missing in the data keep their current values.
+func (so *SmoothOptions) UnmarshalJSON(data []byte) error {
+	type alias SmoothOptions
+	opts := *so
+	d := struct {
+		Version uint16
+		*alias
+	}{SerialVersion, (*alias)(&opts)}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	if d.Version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	*so = opts
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is JSON.
+func (so *SmoothOptions) MarshalText() ([]byte, error) {
+	return so.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (so *SmoothOptions) UnmarshalText(text []byte) error {
+	return so.UnmarshalJSON(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (so *SmoothOptions) MarshalBinary() ([]byte, error) {
+	w := &binWriter{}
+	w.u16(SerialVersion)
+	so.appendBinary(w)
+	return w.buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (so *SmoothOptions) UnmarshalBinary(data []byte) error {
+	r := &binReader{buf: data}
+	version := r.u16()
+	if version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	d := *so
+	d.readBinary(r, version)
+	if r.err != nil {
+		return r.err
+	}
+	*so = d
+	return nil
+}
+
+func (so *SmoothOptions) appendBinary(w *binWriter) {
+	w.f64(so.LerpSpeedX)
+	w.f64(so.LerpSpeedY)
+	w.f64(so.SmoothDampTimeX)
+	w.f64(so.SmoothDampTimeY)
+	w.f64(so.SmoothDampMaxSpeedX)
+	w.f64(so.SmoothDampMaxSpeedY)
+	w.f64(so.SpringFrequencyX)
+	w.f64(so.SpringFrequencyY)
+	w.f64(so.SpringDampingX)
+	w.f64(so.SpringDampingY)
+	w.f64(so.SpringResponseX)
+	w.f64(so.SpringResponseY)
+	w.bool(so.SmoothDampRadial)
+	w.f64(so.LerpSpeedNegX)
+	w.f64(so.LerpSpeedNegY)
+	w.f64(so.SmoothDampTimeNegX)
+	w.f64(so.SmoothDampTimeNegY)
+	w.f64(so.SmoothDampMaxSpeedNegX)
+	w.f64(so.SmoothDampMaxSpeedNegY)
+}
+
+func (so *SmoothOptions) readBinary(r *binReader, version uint16) {
+	so.LerpSpeedX = r.f64()
+	so.LerpSpeedY = r.f64()
+	so.SmoothDampTimeX = r.f64()
+	so.SmoothDampTimeY = r.f64()
+	so.SmoothDampMaxSpeedX = r.f64()
+	so.SmoothDampMaxSpeedY = r.f64()
+	if version >= 4 {
+		so.SpringFrequencyX = r.f64()
+		so.SpringFrequencyY = r.f64()
+		so.SpringDampingX = r.f64()
+		so.SpringDampingY = r.f64()
+		so.SpringResponseX = r.f64()
+		so.SpringResponseY = r.f64()
+	}
+	if version >= 5 {
+		so.SmoothDampRadial = r.bool()
+	}
+	if version >= 7 {
+		so.LerpSpeedNegX = r.f64()
+		so.LerpSpeedNegY = r.f64()
+		so.SmoothDampTimeNegX = r.f64()
+		so.SmoothDampTimeNegY = r.f64()
+		so.SmoothDampMaxSpeedNegX = r.f64()
+		so.SmoothDampMaxSpeedNegY = r.f64()
+	}
+}
+
+func (cam *Camera) data() cameraData {
+	return cameraData{
+		Version:                SerialVersion,
+		Width:                  cam.Width,
+		Height:                 cam.Height,
+		SmoothType:             cam.SmoothType,
+		ShakeEnabled:           cam.ShakeEnabled,
+		XAxisSmoothingDisabled: cam.XAxisSmoothingDisabled,
+		YAxisSmoothingDisabled: cam.YAxisSmoothingDisabled,
+		DeadZoneX:              cam.DeadZoneX,
+		DeadZoneY:              cam.DeadZoneY,
+		MinZoom:                cam.MinZoom,
+		MaxZoom:                cam.MaxZoom,
+		Bounds:                 cam.Bounds,
+		PerAxisSmoothType:      cam.PerAxisSmoothType,
+		SmoothTypeX:            cam.SmoothTypeX,
+		SmoothTypeY:            cam.SmoothTypeY,
+		GroundLock:             cam.GroundLock,
+		GroundLockBandUp:       cam.GroundLockBandUp,
+		GroundLockBandDown:     cam.GroundLockBandDown,
+		HardLimit:              cam.HardLimit,
+		AnchorX:                cam.AnchorX,
+		AnchorY:                cam.AnchorY,
+		AnchorLerpSpeed:        cam.AnchorLerpSpeed,
+		PivotMode:              cam.PivotMode,
+		PivotX:                 cam.PivotX,
+		PivotY:                 cam.PivotY,
+		State:                  cam.Snapshot(),
+		SmoothOptions:          cam.SmoothOptions,
+		ShakeOptions:           cam.ShakeOptions,
+	}
+}
+
+// newData returns the camera data with copies of the options to decode into,
+// so the camera and cameras sharing its options are not changed if decoding fails.
+func (cam *Camera) newData() cameraData {
+	d := cam.data()
+	smooth := *cam.SmoothOptions
+	d.SmoothOptions = &smooth
+	d.ShakeOptions = cam.ShakeOptions.clone()
+	return d
+}
+
+func (cam *Camera) setData(d cameraData) {
+	if d.Version < 10 {
+		// the view was centered before the framing anchor
+		d.AnchorX, d.AnchorY = 0.5, 0.5
+		d.State.CurrentAnchorX, d.State.CurrentAnchorY = 0.5, 0.5
+	}
+	cam.SetSize(d.Width, d.Height)
+	cam.SmoothType = d.SmoothType
+	cam.ShakeEnabled = d.ShakeEnabled
+	cam.XAxisSmoothingDisabled = d.XAxisSmoothingDisabled
+	cam.YAxisSmoothingDisabled = d.YAxisSmoothingDisabled
+	cam.DeadZoneX, cam.DeadZoneY = d.DeadZoneX, d.DeadZoneY
+	cam.MinZoom, cam.MaxZoom = d.MinZoom, d.MaxZoom
+	cam.Bounds = d.Bounds
+	cam.PerAxisSmoothType = d.PerAxisSmoothType
+	cam.SmoothTypeX, cam.SmoothTypeY = d.SmoothTypeX, d.SmoothTypeY
+	cam.GroundLock = d.GroundLock
+	cam.GroundLockBandUp, cam.GroundLockBandDown = d.GroundLockBandUp, d.GroundLockBandDown
+	cam.HardLimit = d.HardLimit
+	cam.SetAnchor(d.AnchorX, d.AnchorY)
+	cam.AnchorLerpSpeed = d.AnchorLerpSpeed
+	cam.PivotMode = d.PivotMode
+	cam.PivotX, cam.PivotY = d.PivotX, d.PivotY
+	cam.SmoothOptions = d.SmoothOptions
+	cam.ShakeOptions = d.ShakeOptions
+	cam.Restore(d.State)
+}
+
+// ensureOptions sets the default options if they are nil, and the default anchor if it is unset.
+func (cam *Camera) ensureOptions() {
+	if cam.SmoothOptions == nil {
+		cam.SmoothOptions = DefaultSmoothOptions()
+	}
+	if cam.ShakeOptions == nil {
+		cam.ShakeOptions = DefaultCameraShakeOptions()
+	}
+	if !cam.anchorSet {
+		cam.SetAnchor(cam.anchor())
+		if cam.CurrentAnchorX == 0 && cam.CurrentAnchorY == 0 {
+			cam.CurrentAnchorX, cam.CurrentAnchorY = cam.AnchorX, cam.AnchorY
+			cam.SetSize(cam.Width, cam.Height)
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The camera configuration, options and the CameraState are serialized.
+func (cam *Camera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cam.data())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Fields missing in the data keep their current values.
+func (cam *Camera) UnmarshalJSON(data []byte) error {
+	cam.ensureOptions()
+	d := cam.newData()
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	if d.Version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	cam.setData(d)
+	cam.ensureOptions()
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is JSON.
+func (cam *Camera) MarshalText() ([]byte, error) {
+	return cam.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (cam *Camera) UnmarshalText(text []byte) error {
+	return cam.UnmarshalJSON(text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (cam *Camera) MarshalBinary() ([]byte, error) {
+	cam.ensureOptions()
+	w := &binWriter{}
+	w.u16(SerialVersion)
+	w.f64(cam.Width)
+	w.f64(cam.Height)
+	w.i64(int64(cam.SmoothType))
+	w.bool(cam.ShakeEnabled)
+	w.bool(cam.XAxisSmoothingDisabled)
+	w.bool(cam.YAxisSmoothingDisabled)
+	s := cam.Snapshot()
+	s.appendBinary(w)
+	w.f64(cam.DeadZoneX)
+	w.f64(cam.DeadZoneY)
+	w.f64(cam.MinZoom)
+	w.f64(cam.MaxZoom)
+	w.rect(cam.Bounds)
+	w.bool(cam.PerAxisSmoothType)
+	w.i64(int64(cam.SmoothTypeX))
+	w.i64(int64(cam.SmoothTypeY))
+	w.bool(cam.GroundLock)
+	w.f64(cam.GroundLockBandUp)
+	w.f64(cam.GroundLockBandDown)
+	w.rect(cam.HardLimit)
+	w.f64(cam.AnchorX)
+	w.f64(cam.AnchorY)
+	w.f64(cam.AnchorLerpSpeed)
+	w.i64(int64(cam.PivotMode))
+	w.f64(cam.PivotX)
+	w.f64(cam.PivotY)
+	cam.SmoothOptions.appendBinary(w)
+	if err := cam.ShakeOptions.appendBinary(w); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (cam *Camera) UnmarshalBinary(data []byte) error {
+	cam.ensureOptions()
+	r := &binReader{buf: data}
+	d := cam.newData()
+	d.Version = r.u16()
+	if d.Version > SerialVersion {
+		return ErrUnsupportedVersion
+	}
+	d.Width = r.f64()
+	d.Height = r.f64()
+	d.SmoothType = SmoothType(r.i64())
+	d.ShakeEnabled = r.bool()
+	d.XAxisSmoothingDisabled = r.bool()
+	d.YAxisSmoothingDisabled = r.bool()
+	d.State.readBinary(r, d.Version)
+	if d.Version >= 2 {
+		d.DeadZoneX = r.f64()
+		d.DeadZoneY = r.f64()
+		d.MinZoom = r.f64()
+		d.MaxZoom = r.f64()
+	}
+	if d.Version >= 3 {
+		d.Bounds = r.rect()
+	}
+	if d.Version >= 6 {
+		d.PerAxisSmoothType = r.bool()
+		d.SmoothTypeX = SmoothType(r.i64())
+		d.SmoothTypeY = SmoothType(r.i64())
+	}
+	if d.Version >= 8 {
+		d.GroundLock = r.bool()
+		d.GroundLockBandUp = r.f64()
+		d.GroundLockBandDown = r.f64()
+	}
+	if d.Version >= 9 {
+		d.HardLimit = r.rect()
+	}
+	if d.Version >= 10 {
+		d.AnchorX = r.f64()
+		d.AnchorY = r.f64()
+		d.AnchorLerpSpeed = r.f64()
+	}
+	if d.Version >= 11 {
+		d.PivotMode = PivotMode(r.i64())
+		d.PivotX = r.f64()
+		d.PivotY = r.f64()
+	}
+	d.SmoothOptions.readBinary(r, d.Version)
+	shake, err := d.ShakeOptions.data()
+	if err != nil {
+		return err
+	}
+	shake.readBinary(r, d.Version)
+	if r.err != nil {
+		return r.err
+	}
+	if err := d.ShakeOptions.setData(shake); err != nil {
+		return err
+	}
+	cam.setData(d)
+	return nil
+}
+
+func (s *CameraState) appendBinary(w *binWriter) {
+	w.f64(s.X)
+	w.f64(s.Y)
+	w.f64(s.Angle)
+	w.f64(s.ActualAngle)
+	w.f64(s.ZoomFactor)
+	w.f64(s.ZoomFactorShake)
+	w.f64(s.Trauma)
+	w.f64(s.TraumaX)
+	w.f64(s.TraumaY)
+	w.f64(s.TraumaAngle)
+	w.f64(s.TraumaZoom)
+	w.f64(s.TraumaOffsetX)
+	w.f64(s.TraumaOffsetY)
+	w.f64(s.Tick)
+	w.u64(s.ShakeFrame)
+	w.f64(s.TempTargetX)
+	w.f64(s.TempTargetY)
+	w.f64(s.CurrentVelocityX)
+	w.f64(s.CurrentVelocityY)
+	w.f64(s.PrevTargetX)
+	w.f64(s.PrevTargetY)
+	w.f64(s.GroundLockY)
+	w.f64(s.CurrentAnchorX)
+	w.f64(s.CurrentAnchorY)
+	w.i64(int64(s.ActiveZone))
+	for _, z := range s.Zones {
+		w.i64(int64(z.Zone))
+		w.f64(z.Progress)
+	}
+	w.f64(s.ZoneBaseZoom)
+	w.f64(s.ZoneZoomScale)
+	w.f64(s.LastZoomFactor)
+	w.bool(s.FlipScreenInit)
+	w.bool(s.FlipScreenSliding)
+	w.bool(s.FlipScreenSlideX)
+	w.bool(s.FlipScreenSlideY)
+	w.i64(int64(s.FlipScreenCellX))
+	w.i64(int64(s.FlipScreenCellY))
+	w.f64(s.FlipScreenX)
+	w.f64(s.FlipScreenY)
+	w.f64(s.FlipScreenFromX)
+	w.f64(s.FlipScreenFromY)
+	w.f64(s.FlipScreenElapsed)
+	w.bool(s.AutoScrollInit)
+	w.f64(s.AutoScrollOriginX)
+	w.f64(s.AutoScrollOriginY)
+	w.f64(s.AutoScrollElapsed)
+	w.f64(s.AutoScrollDistance)
+	w.bool(s.PresetBlending)
+	w.f64(s.PresetBlendElapsed)
+	w.f64(s.PresetBlendDuration)
+	s.PresetBlendFrom.appendBinary(w)
+	s.PresetBlendTo.appendBinary(w)
+}
+
+func (s *CameraState) readBinary(r *binReader, version uint16) {
+	s.X = r.f64()
+	s.Y = r.f64()
+	s.Angle = r.f64()
+	s.ActualAngle = r.f64()
+	s.ZoomFactor = r.f64()
+	s.ZoomFactorShake = r.f64()
+	s.Trauma = r.f64()
+	s.TraumaX = r.f64()
+	s.TraumaY = r.f64()
+	s.TraumaAngle = r.f64()
+	s.TraumaZoom = r.f64()
+	s.TraumaOffsetX = r.f64()
+	s.TraumaOffsetY = r.f64()
+	s.Tick = r.f64()
+	s.ShakeFrame = r.u64()
+	s.TempTargetX = r.f64()
+	s.TempTargetY = r.f64()
+	s.CurrentVelocityX = r.f64()
+	s.CurrentVelocityY = r.f64()
+	if version >= 4 {
+		s.PrevTargetX = r.f64()
+		s.PrevTargetY = r.f64()
+	}
+	if version >= 8 {
+		s.GroundLockY = r.f64()
+	}
+	if version >= 10 {
+		s.CurrentAnchorX = r.f64()
+		s.CurrentAnchorY = r.f64()
+	}
+	if version >= 12 {
+		s.ActiveZone = int(r.i64())
+		for i := range s.Zones {
+			s.Zones[i].Zone = int(r.i64())
+			s.Zones[i].Progress = r.f64()
+		}
+		s.ZoneBaseZoom = r.f64()
+		s.ZoneZoomScale = r.f64()
+		s.LastZoomFactor = r.f64()
+	}
+	if version >= 13 {
+		s.FlipScreenInit = r.bool()
+		s.FlipScreenSliding = r.bool()
+		s.FlipScreenSlideX = r.bool()
+		s.FlipScreenSlideY = r.bool()
+		s.FlipScreenCellX = int(r.i64())
+		s.FlipScreenCellY = int(r.i64())
+		s.FlipScreenX = r.f64()
+		s.FlipScreenY = r.f64()
+		s.FlipScreenFromX = r.f64()
+		s.FlipScreenFromY = r.f64()
+		s.FlipScreenElapsed = r.f64()
+	}
+	if version >= 14 {
+		s.AutoScrollInit = r.bool()
+		s.AutoScrollOriginX = r.f64()
+		s.AutoScrollOriginY = r.f64()
+		s.AutoScrollElapsed = r.f64()
+		s.AutoScrollDistance = r.f64()
+	}
+	if version >= 15 {
+		s.PresetBlending = r.bool()
+		s.PresetBlendElapsed = r.f64()
+	}
+	if version >= 16 {
+		s.PresetBlendDuration = r.f64()
+		s.PresetBlendFrom.readBinary(r, version)
+		s.PresetBlendTo.readBinary(r, version)
+	}
+}
+
+func (p *PresetBlendParams) appendBinary(w *binWriter) {
+	p.SmoothOptions.appendBinary(w)
+	w.f64(p.DeadZoneX)
+	w.f64(p.DeadZoneY)
+	w.f64(p.ShakeMaxX)
+	w.f64(p.ShakeMaxY)
+	w.f64(p.ShakeMaxAngle)
+	w.f64(p.ShakeMaxZoomFactor)
+	w.f64(p.ShakeTimeScale)
+	w.f64(p.ShakeDecay)
+	w.f64(p.ShakeTraumaExponent)
+}
+
+func (p *PresetBlendParams) readBinary(r *binReader, version uint16) {
+	p.SmoothOptions.readBinary(r, version)
+	p.DeadZoneX = r.f64()
+	p.DeadZoneY = r.f64()
+	p.ShakeMaxX = r.f64()
+	p.ShakeMaxY = r.f64()
+	p.ShakeMaxAngle = r.f64()
+	p.ShakeMaxZoomFactor = r.f64()
+	p.ShakeTimeScale = r.f64()
+	p.ShakeDecay = r.f64()
+	p.ShakeTraumaExponent = r.f64()
+}
+
+// binWriter appends little-endian values to a buffer.
+type binWriter struct {
+	buf []byte
+}
+
+func (w *binWriter) u16(v uint16) {
+	w.buf = binary.LittleEndian.AppendUint16(w.buf, v)
+}
+
+func (w *binWriter) u64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *binWriter) i64(v int64) {
+	w.u64(uint64(v))
+}
+
+func (w *binWriter) f64(v float64) {
+	w.u64(math.Float64bits(v))
+}
+
+func (w *binWriter) rect(v Rect) {
+	w.f64(v.X)
+	w.f64(v.Y)
+	w.f64(v.Width)
+	w.f64(v.Height)
+}
+
+func (w *binWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// binReader reads little-endian values from a buffer.
+//
+// After the first error, all reads return zero and err is set.
+type binReader struct {
+	buf []byte
+	err error
+}
+
+func (r *binReader) next(n int) []byte {
+	if r.err != nil || len(r.buf) < n {
+		r.err = ErrShortData
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *binReader) u16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *binReader) u64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *binReader) i64() int64 {
+	return int64(r.u64())
+}
+
+func (r *binReader) f64() float64 {
+	return math.Float64frombits(r.u64())
+}
+
+func (r *binReader) rect() Rect {
+	return Rect{X: r.f64(), Y: r.f64(), Width: r.f64(), Height: r.f64()}
+}
+
+func (r *binReader) bool() bool {
+	if b := r.next(1); b != nil {
+		return b[0] != 0
+	}
+	return false
+}
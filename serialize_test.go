@@ -0,0 +1,176 @@
+package kamera_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/setanarut/fastnoise"
+	"github.com/setanarut/kamera/v2"
+)
+
+func newTestCamera() *kamera.Camera {
+	k := kamera.NewCamera(10, 20, 320, 240)
+	k.SmoothType = kamera.SmoothDamp
+	k.ShakeEnabled = true
+	k.SmoothOptions.SmoothDampTimeX = 0.5
+	k.ShakeOptions.MaxAngle = 0.3
+	k.ShakeOptions.DecayType = kamera.ExponentialDecay
+	k.ShakeOptions.Noise.SetNoiseType(fastnoise.Perlin)
+	k.ShakeOptions.Noise.SetFractalType(fastnoise.FractalFBm)
+	k.SeedShake(99)
+	k.AddTrauma(0.7)
+	k.LookAt(100, 50)
+	return k
+}
+
+func checkCamera(t *testing.T, a, b *kamera.Camera) {
+	t.Helper()
+	if a.Snapshot() != b.Snapshot() || *a.SmoothOptions != *b.SmoothOptions {
+		t.Error("state or smooth options mismatch")
+	}
+	if b.Width != 320 || b.CenterOffsetX != -160 || b.SmoothType != kamera.SmoothDamp || !b.ShakeEnabled {
+		t.Error("config mismatch")
+	}
+	if b.ShakeOptions.MaxAngle != 0.3 || b.ShakeOptions.DecayType != kamera.ExponentialDecay ||
+		b.ShakeOptions.Noise.Seed != 99 ||
+		b.ShakeOptions.Noise.Frequency != a.ShakeOptions.Noise.Frequency {
+		t.Error("shake options mismatch")
+	}
+	// the noise and fractal types are kept
+	if fastnoise.Value3D(1.3, 2.1, 0.7, a.ShakeOptions.Noise) != fastnoise.Value3D(1.3, 2.1, 0.7, b.ShakeOptions.Noise) {
+		t.Error("noise type mismatch")
+	}
+}
+
+func TestCameraJSON(t *testing.T) {
+	a := newTestCamera()
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := kamera.NewCamera(0, 0, 10, 10)
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatal(err)
+	}
+	checkCamera(t, a, b)
+}
+
+func TestCameraBinary(t *testing.T) {
+	a := newTestCamera()
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := kamera.NewCamera(0, 0, 10, 10)
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	checkCamera(t, a, b)
+	if err := b.UnmarshalBinary(data[:20]); err != kamera.ErrShortData {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalVersion(t *testing.T) {
+	so := kamera.DefaultSmoothOptions()
+	if err := json.Unmarshal([]byte(`{"LerpSpeedX":0.5}`), so); err != nil || so.LerpSpeedX != 0.5 || so.LerpSpeedY != 0.09 {
+		t.Error(err)
+	}
+	if err := json.Unmarshal([]byte(`{"Version":999,"LerpSpeedX":0.1}`), so); err != kamera.ErrUnsupportedVersion || so.LerpSpeedX != 0.5 {
+		t.Error(err, so.LerpSpeedX)
+	}
+}
+
+func TestUnmarshalAnchorBeforeVersion10(t *testing.T) {
+	data, err := json.Marshal(newTestCamera())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	m["Version"] = 9
+	delete(m, "AnchorX")
+	delete(m, "AnchorY")
+	state := m["State"].(map[string]any)
+	delete(state, "CurrentAnchorX")
+	delete(state, "CurrentAnchorY")
+	if data, err = json.Marshal(m); err != nil {
+		t.Fatal(err)
+	}
+
+	var k kamera.Camera
+	if err := json.Unmarshal(data, &k); err != nil {
+		t.Fatal(err)
+	}
+	if k.AnchorX != 0.5 || k.CurrentAnchorY != 0.5 || k.CenterOffsetX != -160 {
+		t.Error(k.AnchorX, k.CurrentAnchorY, k.CenterOffsetX)
+	}
+	k.SmoothType, k.ShakeEnabled = kamera.None, false
+	k.LookAt(100, 50)
+	if x, y := k.Center(); x != 100 || y != 50 {
+		t.Error(x, y)
+	}
+}
+
+func TestUnmarshalBinaryVersion12(t *testing.T) {
+	a := newTestCamera()
+	a.DeadZoneX = 7
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// version 12 has no flip-screen, auto-scroll and preset blend state
+	const zonesEnd = 2 + 3*8 + 3 + 24*8 + 8 + kamera.MaxSnapshotZones*16 + 3*8
+	const presetParamsSize = 18*8 + 1 + 9*8
+	const stateEnd = zonesEnd + 4 + 2*8 + 5*8 + 1 + 4*8 + 1 + 2*8 + 2*presetParamsSize
+	old := append([]byte{12, 0}, data[2:zonesEnd]...)
+	old = append(old, data[stateEnd:]...)
+
+	b := kamera.NewCamera(0, 0, 10, 10)
+	if err := b.UnmarshalBinary(old); err != nil {
+		t.Fatal(err)
+	}
+	if b.DeadZoneX != 7 || b.X != a.X || *b.SmoothOptions != *a.SmoothOptions || b.ShakeOptions.Noise.Seed != 99 {
+		t.Error(b.DeadZoneX, b.X)
+	}
+}
+
+func TestUnmarshalCameraKeepsOptions(t *testing.T) {
+	a := newTestCamera()
+	b := kamera.NewCamera(0, 0, 10, 10)
+	b.SmoothOptions = a.SmoothOptions
+	b.ShakeOptions = a.ShakeOptions
+	smooth, maxX := *a.SmoothOptions, a.ShakeOptions.MaxX
+
+	data := []byte(`{"Version":99,"SmoothOptions":{"LerpSpeedX":0.7},"ShakeOptions":{"MaxX":77}}`)
+	if err := json.Unmarshal(data, b); err != kamera.ErrUnsupportedVersion {
+		t.Error(err)
+	}
+	if *b.SmoothOptions != smooth || b.ShakeOptions.MaxX != maxX {
+		t.Error("options changed by a rejected payload")
+	}
+
+	// decoding does not change the options shared with another camera
+	data, err := json.Marshal(newTestCamera())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SmoothOptions.LerpSpeedX = 0.3
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatal(err)
+	}
+	bin, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SmoothOptions = a.SmoothOptions
+	b.ShakeOptions = a.ShakeOptions
+	if err := b.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	if a.SmoothOptions.LerpSpeedX != 0.3 || b.SmoothOptions == a.SmoothOptions || b.ShakeOptions == a.ShakeOptions {
+		t.Error(a.SmoothOptions.LerpSpeedX)
+	}
+}
@@ -0,0 +1,111 @@
+package kamera
+
+import "math"
+
+// Falloff is the distance falloff curve type of a ShakeSource.
+type Falloff int
+
+const (
+	// LinearFalloff decreases trauma linearly from InnerRadius to Radius.
+	LinearFalloff Falloff = iota
+	// InverseSquareFalloff decreases trauma with the inverse square of the distance.
+	InverseSquareFalloff
+	// CustomFalloff uses ShakeSource.FalloffFunc.
+	CustomFalloff
+)
+
+// ShakeSource is a shake emitted at a world-space position.
+//
+// Use Camera.AddTraumaAt() to emit it.
+type ShakeSource struct {
+	// X is the world-space X position of the source
+	X float64
+	// Y is the world-space Y position of the source
+	Y float64
+	// Trauma is the trauma factor at the source. Factor is in the range [0-1].
+	Trauma float64
+	// Radius is the distance at which the trauma reaches zero.
+	Radius float64
+	// InnerRadius is the distance within which the full trauma is applied.
+	//
+	// For InverseSquareFalloff, 0 means Radius * 0.1
+	InnerRadius float64
+	// Falloff is the distance falloff curve type.
+	Falloff Falloff
+	// FalloffFunc maps the normalized distance [0-1] (0 at InnerRadius, 1 at Radius) to a trauma scale [0-1].
+	//
+	// Used only with CustomFalloff.
+	FalloffFunc func(t float64) float64
+	// Channels are the shake channels affected by the source. 0 means ShakeAll.
+	Channels ShakeChannel
+	// If Directional is true, the translation trauma is distributed to the X and Y channels
+	// along the direction from the source to the camera center.
+	Directional bool
+}
+
+// Attenuation returns the trauma scale [0-1] of the source at the given distance.
+func (s *ShakeSource) Attenuation(distance float64) float64 {
+	inner := max(s.InnerRadius, 0)
+	if distance <= inner {
+		return 1
+	}
+	if distance >= s.Radius {
+		return 0
+	}
+	switch s.Falloff {
+	case InverseSquareFalloff:
+		if inner == 0 {
+			inner = s.Radius * 0.1
+			if distance <= inner {
+				return 1
+			}
+		}
+		edge := (inner * inner) / (s.Radius * s.Radius)
+		return ((inner*inner)/(distance*distance) - edge) / (1 - edge)
+	case CustomFalloff:
+		if s.FalloffFunc == nil {
+			return 0
+		}
+		return min(max(s.FalloffFunc((distance-inner)/(s.Radius-inner)), 0), 1)
+	default:
+		return 1 - (distance-inner)/(s.Radius-inner)
+	}
+}
+
+// AddTraumaAt adds trauma emitted from a world-space position.
+//
+// The trauma is scaled by the distance between the source and the camera center.
+func (cam *Camera) AddTraumaAt(src ShakeSource) {
+	if !cam.ShakeEnabled {
+		return
+	}
+	dx, dy := cam.CenterX()-src.X, cam.CenterY()-src.Y
+	dist := math.Hypot(dx, dy)
+	factor := src.Trauma * src.Attenuation(dist)
+	if factor <= 0 {
+		return
+	}
+	channels := src.Channels
+	if channels == 0 {
+		channels = ShakeAll
+	}
+	if !src.Directional {
+		if channels == ShakeAll {
+			cam.AddTrauma(factor)
+		} else {
+			cam.AddChannelTrauma(factor, channels)
+		}
+		return
+	}
+	wx, wy := math.Sqrt2/2, math.Sqrt2/2
+	if dist > 0 {
+		wx, wy = math.Abs(dx)/dist, math.Abs(dy)/dist
+	}
+	if channels&ShakeX != 0 {
+		cam.AddChannelTrauma(factor*wx, ShakeX)
+	}
+	if channels&ShakeY != 0 {
+		cam.AddChannelTrauma(factor*wy, ShakeY)
+	}
+	cam.AddChannelTrauma(factor, channels&(ShakeAngle|ShakeZoom))
+}
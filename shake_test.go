@@ -0,0 +1,45 @@
+package kamera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestAddTraumaAt(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ShakeEnabled = true
+	k.AddTraumaAt(kamera.ShakeSource{X: 500, Y: 0, Trauma: 1, Radius: 200})
+	if k.Trauma != 0 {
+		t.Error()
+	}
+	k.AddTraumaAt(kamera.ShakeSource{X: 100, Y: 0, Trauma: 1, Radius: 200})
+	if k.Trauma != 0.5 {
+		t.Error(k.Trauma)
+	}
+}
+
+func TestAddTraumaAtDirectional(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ShakeEnabled = true
+	k.AddTraumaAt(kamera.ShakeSource{X: 0, Y: 10, Trauma: 1, Radius: 200, InnerRadius: 50, Directional: true})
+	if k.TraumaX != 0 || k.TraumaY != 1 || k.TraumaAngle != 1 {
+		t.Error()
+	}
+}
+
+func TestAttenuation(t *testing.T) {
+	s := kamera.ShakeSource{Radius: 100, InnerRadius: 10, Falloff: kamera.InverseSquareFalloff}
+	if s.Attenuation(5) != 1 || s.Attenuation(100) != 0 {
+		t.Error()
+	}
+	if a := s.Attenuation(20); a <= 0 || a >= 0.5 {
+		t.Error(a)
+	}
+	s.Falloff = kamera.CustomFalloff
+	s.FalloffFunc = func(t float64) float64 { return math.Cos(t * math.Pi / 2) }
+	if math.Abs(s.Attenuation(55)-math.Cos(math.Pi/4)) > 1e-9 {
+		t.Error()
+	}
+}
@@ -0,0 +1,237 @@
+package kamera
+
+import "slices"
+
+// MaxSnapshotZones is the number of active and fading zones kept in a CameraState.
+//
+// If more zones are fading at the same time, the lowest priority ones are not kept.
+const MaxSnapshotZones = 8
+
+// ZoneBlendState is the blend state of an active or fading zone in a CameraState.
+type ZoneBlendState struct {
+	// Zone is the index of the zone in Camera.Zones plus one. 0 means unused.
+	Zone int
+	// Progress is the linear blend progress in the range [0-1].
+	Progress float64
+}
+
+// PresetBlendParams holds the blended parameters of a preset blend in a CameraState.
+type PresetBlendParams struct {
+	SmoothOptions                     SmoothOptions
+	DeadZoneX, DeadZoneY              float64
+	ShakeMaxX, ShakeMaxY              float64
+	ShakeMaxAngle, ShakeMaxZoomFactor float64
+	ShakeTimeScale, ShakeDecay        float64
+	ShakeTraumaExponent               float64
+}
+
+// CameraState is a compact snapshot of the mutable camera state.
+//
+// It holds no pointers, so it can be copied and compared freely.
+// Configuration (SmoothOptions, ShakeOptions, size, zones) is not included.
+// Zones are referenced by their index in Camera.Zones.
+type CameraState struct {
+	X, Y                               float64
+	Angle, ActualAngle                 float64
+	ZoomFactor, ZoomFactorShake        float64
+	Trauma                             float64
+	TraumaX, TraumaY                   float64
+	TraumaAngle, TraumaZoom            float64
+	TraumaOffsetX, TraumaOffsetY       float64
+	Tick                               float64
+	ShakeFrame                         uint64
+	TempTargetX, TempTargetY           float64
+	CurrentVelocityX, CurrentVelocityY float64
+	PrevTargetX, PrevTargetY           float64
+	GroundLockY                        float64
+	CurrentAnchorX, CurrentAnchorY     float64
+
+	// ActiveZone is the index of the active zone in Camera.Zones plus one. 0 means none.
+	ActiveZone int
+	// Zones are the active and fading zones in blend order.
+	Zones                       [MaxSnapshotZones]ZoneBlendState
+	ZoneBaseZoom, ZoneZoomScale float64
+	// LastZoomFactor is the ZoomFactor after the last LookAt() call.
+	LastZoomFactor float64
+
+	// Flip-screen cell and slide animation.
+	FlipScreenInit, FlipScreenSliding  bool
+	FlipScreenSlideX, FlipScreenSlideY bool
+	FlipScreenCellX, FlipScreenCellY   int
+	FlipScreenX, FlipScreenY           float64
+	FlipScreenFromX, FlipScreenFromY   float64
+	FlipScreenElapsed                  float64
+
+	// Auto-scroll position.
+	AutoScrollInit                        bool
+	AutoScrollOriginX, AutoScrollOriginY  float64
+	AutoScrollElapsed, AutoScrollDistance float64
+
+	// PresetBlending is true while a preset blend is in progress.
+	PresetBlending bool
+	// PresetBlendElapsed and PresetBlendDuration are the elapsed time and duration of the preset blend in seconds.
+	PresetBlendElapsed, PresetBlendDuration float64
+	// PresetBlendFrom and PresetBlendTo are the parameters the preset blend interpolates between.
+	PresetBlendFrom, PresetBlendTo PresetBlendParams
+}
+
+// Snapshot returns the current camera state.
+//
+// Use it with Restore() for rollback netcode and replays.
+func (cam *Camera) Snapshot() CameraState {
+	s := CameraState{
+		X:                cam.X,
+		Y:                cam.Y,
+		Angle:            cam.Angle,
+		ActualAngle:      cam.ActualAngle,
+		ZoomFactor:       cam.ZoomFactor,
+		ZoomFactorShake:  cam.ZoomFactorShake,
+		Trauma:           cam.Trauma,
+		TraumaX:          cam.TraumaX,
+		TraumaY:          cam.TraumaY,
+		TraumaAngle:      cam.TraumaAngle,
+		TraumaZoom:       cam.TraumaZoom,
+		TraumaOffsetX:    cam.TraumaOffsetX,
+		TraumaOffsetY:    cam.TraumaOffsetY,
+		Tick:             cam.Tick,
+		ShakeFrame:       cam.ShakeFrame,
+		TempTargetX:      cam.TempTargetX,
+		TempTargetY:      cam.TempTargetY,
+		CurrentVelocityX: cam.CurrentVelocityX,
+		CurrentVelocityY: cam.CurrentVelocityY,
+		PrevTargetX:      cam.PrevTargetX,
+		PrevTargetY:      cam.PrevTargetY,
+		GroundLockY:      cam.GroundLockY,
+		CurrentAnchorX:   cam.CurrentAnchorX,
+		CurrentAnchorY:   cam.CurrentAnchorY,
+	}
+	s.ActiveZone = cam.zoneIndex(cam.activeZone)
+	zones := cam.zoneStates[max(0, len(cam.zoneStates)-MaxSnapshotZones):]
+	for i, z := range zones {
+		s.Zones[i] = ZoneBlendState{Zone: cam.zoneIndex(z.zone), Progress: z.progress}
+	}
+	s.ZoneBaseZoom, s.ZoneZoomScale = cam.zoneBaseZoom, cam.zoneZoomScale
+	s.LastZoomFactor = cam.lastZoom
+
+	f := cam.flipScreen
+	s.FlipScreenInit, s.FlipScreenSliding = f.init, f.sliding
+	s.FlipScreenSlideX, s.FlipScreenSlideY = f.slideX, f.slideY
+	s.FlipScreenCellX, s.FlipScreenCellY = f.cellX, f.cellY
+	s.FlipScreenX, s.FlipScreenY = f.x, f.y
+	s.FlipScreenFromX, s.FlipScreenFromY = f.fromX, f.fromY
+	s.FlipScreenElapsed = f.elapsed
+
+	a := cam.autoScroll
+	s.AutoScrollInit = a.init
+	s.AutoScrollOriginX, s.AutoScrollOriginY = a.originX, a.originY
+	s.AutoScrollElapsed, s.AutoScrollDistance = a.elapsed, a.distance
+
+	if b := cam.presetBlend; b != nil {
+		s.PresetBlending = true
+		s.PresetBlendElapsed, s.PresetBlendDuration = b.elapsed, b.duration
+		s.PresetBlendFrom, s.PresetBlendTo = b.from.state(), b.to.state()
+	}
+	return s
+}
+
+// Restore sets the camera state from a snapshot taken with Snapshot().
+//
+// The zone callbacks are not called. A preset blend is restored with its parameters,
+// but the non-blended preset settings are configuration and are not changed.
+func (cam *Camera) Restore(s CameraState) {
+	cam.X, cam.Y = s.X, s.Y
+	cam.Angle, cam.ActualAngle = s.Angle, s.ActualAngle
+	cam.ZoomFactor, cam.ZoomFactorShake = s.ZoomFactor, s.ZoomFactorShake
+	cam.Trauma = s.Trauma
+	cam.TraumaX, cam.TraumaY = s.TraumaX, s.TraumaY
+	cam.TraumaAngle, cam.TraumaZoom = s.TraumaAngle, s.TraumaZoom
+	cam.TraumaOffsetX, cam.TraumaOffsetY = s.TraumaOffsetX, s.TraumaOffsetY
+	cam.Tick, cam.ShakeFrame = s.Tick, s.ShakeFrame
+	cam.TempTargetX, cam.TempTargetY = s.TempTargetX, s.TempTargetY
+	cam.CurrentVelocityX, cam.CurrentVelocityY = s.CurrentVelocityX, s.CurrentVelocityY
+	cam.PrevTargetX, cam.PrevTargetY = s.PrevTargetX, s.PrevTargetY
+	cam.GroundLockY = s.GroundLockY
+	cam.CurrentAnchorX, cam.CurrentAnchorY = s.CurrentAnchorX, s.CurrentAnchorY
+	cam.CenterOffsetX = -(cam.Width * cam.CurrentAnchorX)
+	cam.CenterOffsetY = -(cam.Height * cam.CurrentAnchorY)
+
+	cam.activeZone = cam.zoneAt(s.ActiveZone)
+	clear(cam.zoneStates)
+	cam.zoneStates = cam.zoneStates[:0]
+	for _, z := range s.Zones {
+		if zone := cam.zoneAt(z.Zone); zone != nil {
+			cam.zoneStates = append(cam.zoneStates, zoneState{zone: zone, progress: z.Progress})
+		}
+	}
+	cam.zoneBaseZoom, cam.zoneZoomScale = s.ZoneBaseZoom, s.ZoneZoomScale
+	cam.lastZoom = s.LastZoomFactor
+
+	cam.flipScreen = flipScreenState{
+		init:    s.FlipScreenInit,
+		cellX:   s.FlipScreenCellX,
+		cellY:   s.FlipScreenCellY,
+		x:       s.FlipScreenX,
+		y:       s.FlipScreenY,
+		fromX:   s.FlipScreenFromX,
+		fromY:   s.FlipScreenFromY,
+		elapsed: s.FlipScreenElapsed,
+		sliding: s.FlipScreenSliding,
+		slideX:  s.FlipScreenSlideX,
+		slideY:  s.FlipScreenSlideY,
+	}
+	cam.autoScroll = autoScrollState{
+		init:     s.AutoScrollInit,
+		originX:  s.AutoScrollOriginX,
+		originY:  s.AutoScrollOriginY,
+		elapsed:  s.AutoScrollElapsed,
+		distance: s.AutoScrollDistance,
+	}
+
+	if !s.PresetBlending {
+		cam.presetBlend = nil
+		return
+	}
+	if cam.presetBlend == nil {
+		cam.presetBlend = &presetBlend{}
+	}
+	*cam.presetBlend = presetBlend{
+		from:     s.PresetBlendFrom.params(),
+		to:       s.PresetBlendTo.params(),
+		elapsed:  s.PresetBlendElapsed,
+		duration: s.PresetBlendDuration,
+	}
+}
+
+// zoneIndex returns the index of the zone in Zones plus one, or 0 if it is not found.
+func (cam *Camera) zoneIndex(z *Zone) int {
+	if z == nil {
+		return 0
+	}
+	return slices.Index(cam.Zones, z) + 1
+}
+
+// zoneAt returns the zone at the index returned by zoneIndex(), or nil if it is out of range.
+func (cam *Camera) zoneAt(i int) *Zone {
+	if i < 1 || i > len(cam.Zones) {
+		return nil
+	}
+	return cam.Zones[i-1]
+}
+
+// SeedShake makes the camera shake deterministic from the seed and resets the shake frame index to zero.
+//
+// The camera gets its own copy of ShakeOptions and the noise state,
+// so cameras sharing the same options are not affected.
+func (cam *Camera) SeedShake(seed int) {
+	cam.ShakeOptions = cam.ShakeOptions.clone()
+	cam.ShakeOptions.Noise.Seed = seed
+	cam.SetShakeFrame(0)
+}
+
+// SetShakeFrame sets the frame index of the shake noise.
+//
+// With the same seed and frame index, the shake is always the same.
+func (cam *Camera) SetShakeFrame(frame uint64) {
+	cam.ShakeFrame = frame
+	cam.Tick = float64(frame%tickWrapFrames) * deltaTime
+}
@@ -0,0 +1,177 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.ShakeEnabled = true
+	k.SmoothType = kamera.SmoothDamp
+	k.SeedShake(42)
+	k.AddTrauma(1)
+	k.LookAt(50, 20)
+	s := k.Snapshot()
+
+	k.LookAt(80, 90)
+	x1, y1 := k.X, k.Y
+
+	k.Restore(s)
+	if k.Snapshot() != s {
+		t.Error()
+	}
+	k.LookAt(80, 90)
+	if k.X != x1 || k.Y != y1 {
+		t.Error()
+	}
+}
+
+func TestSeedShake(t *testing.T) {
+	a := kamera.NewCamera(0, 0, 100, 100)
+	b := kamera.NewCamera(0, 0, 100, 100)
+	b.ShakeOptions = a.ShakeOptions
+	for _, k := range []*kamera.Camera{a, b} {
+		k.ShakeEnabled = true
+		k.SeedShake(7)
+		k.SetShakeFrame(120)
+		k.AddTrauma(1)
+		k.LookAt(0, 0)
+	}
+	if a.ShakeOptions == b.ShakeOptions || a.TraumaOffsetX != b.TraumaOffsetX || a.ActualAngle != b.ActualAngle {
+		t.Error()
+	}
+}
+
+// checkRollback takes a snapshot after warmup, runs step, restores the snapshot and runs step again.
+func checkRollback(t *testing.T, k *kamera.Camera, warmup, step func()) {
+	t.Helper()
+	warmup()
+	s := k.Snapshot()
+	step()
+	want := k.Snapshot()
+	k.Restore(s)
+	if k.Snapshot() != s {
+		t.Error("restored state mismatch")
+	}
+	step()
+	if got := k.Snapshot(); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// the state survives serialization
+	k.Restore(s)
+	data, err := k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.SetCenter(-500, -500)
+	if err := k.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if k.Snapshot() != s {
+		t.Error("serialized state mismatch")
+	}
+}
+
+func TestSnapshotZones(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	a := kamera.NewZone("a", kamera.Rect{X: 0, Y: 0, Width: 100, Height: 100})
+	a.LockX, a.Zoom = true, 2
+	b := kamera.NewZone("b", kamera.Rect{X: 100, Y: 0, Width: 100, Height: 100})
+	b.LockY, b.Priority = true, 1
+	k.Zones = append(k.Zones, a, b)
+	checkRollback(t, k, func() {
+		for range 20 {
+			k.LookAt(10, 10)
+		}
+		k.LookAt(150, 10)
+		k.ZoomFactor *= 1.5
+	}, func() {
+		for range 10 {
+			k.LookAt(150, 10)
+		}
+		k.LookAt(500, 10)
+	})
+}
+
+func TestSnapshotFlipScreen(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.FlipScreen = kamera.DefaultFlipScreenOptions()
+	k.FlipScreen.SlideDuration = 0.5
+	checkRollback(t, k, func() {
+		k.LookAt(10, 10)
+		k.LookAt(120, 10)
+		k.LookAt(120, 10)
+	}, func() {
+		for range 10 {
+			k.LookAt(120, 130)
+		}
+	})
+}
+
+func TestSnapshotAutoScroll(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.AutoScroll = kamera.DefaultAutoScrollOptions()
+	k.AutoScroll.SpeedOverTime = func(elapsed float64) float64 { return 10 + elapsed*100 }
+	checkRollback(t, k, func() {
+		for range 10 {
+			k.LookAt(0, 0)
+		}
+	}, func() {
+		for range 10 {
+			k.LookAt(0, 0)
+		}
+	})
+}
+
+func TestSnapshotPresetBlend(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.Lerp
+	p := kamera.NewPreset("slow")
+	p.SmoothOptions = &kamera.SmoothOptions{LerpSpeedX: 0.01, LerpSpeedY: 0.01}
+	checkRollback(t, k, func() {
+		k.SetPreset(p, 1)
+		for range 10 {
+			k.LookAt(100, 100)
+		}
+	}, func() {
+		for range 10 {
+			k.LookAt(100, 100)
+		}
+	})
+	if !k.IsBlendingPreset() || k.Snapshot().PresetBlendElapsed <= 0 {
+		t.Error(k.IsBlendingPreset(), k.Snapshot().PresetBlendElapsed)
+	}
+}
+
+func TestSnapshotFinishedPresetBlend(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.SmoothType = kamera.Lerp
+	p := kamera.NewPreset("slow")
+	p.SmoothOptions = &kamera.SmoothOptions{LerpSpeedX: 0.01, LerpSpeedY: 0.01}
+	k.SetPreset(p, 0.5)
+	for range 10 {
+		k.LookAt(100, 100)
+	}
+	s := k.Snapshot()
+	for range 30 {
+		k.LookAt(100, 100)
+	}
+	if k.IsBlendingPreset() {
+		t.Fatal("blend not finished")
+	}
+	want := k.Snapshot()
+
+	k.Restore(s)
+	if !k.IsBlendingPreset() {
+		t.Fatal("blend not restored")
+	}
+	for range 30 {
+		k.LookAt(100, 100)
+	}
+	if got := k.Snapshot(); got != want || k.SmoothOptions.LerpSpeedX != 0.01 {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
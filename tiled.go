@@ -0,0 +1,397 @@
+package kamera
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tiled object classes. The class (or type) of an object is case-insensitive.
+const (
+	// TiledBounds is the class of the rectangle object used as the camera world bounds.
+	//
+	// If the layer has no bounds object, the map size is used (except for infinite maps).
+	TiledBounds = "bounds"
+	// TiledZone is the class of the rectangle objects imported as camera zones.
+	//
+	// Rectangle objects without a class are zones too.
+	TiledZone = "zone"
+	// TiledRail is the class of the polyline and polygon objects imported as camera rails.
+	//
+	// Polyline and polygon objects without a class are rails too.
+	TiledRail = "rail"
+	// TiledPoint is the class of the point objects imported as focus points.
+	//
+	// Point objects without a class are focus points too.
+	TiledPoint = "point"
+)
+
+// gidMask clears the flip flags of a Tiled global tile ID.
+const gidMask uint32 = 0x0fffffff
+
+// ErrLayerNotFound is returned when the object layer does not exist in the map.
+var ErrLayerNotFound = errors.New("kamera: object layer not found")
+
+type tmxMap struct {
+	Width        int              `xml:"width,attr"`
+	Height       int              `xml:"height,attr"`
+	TileWidth    int              `xml:"tilewidth,attr"`
+	TileHeight   int              `xml:"tileheight,attr"`
+	Infinite     int              `xml:"infinite,attr"`
+	Tilesets     []tmxTileset     `xml:"tileset"`
+	ObjectGroups []tmxObjectGroup `xml:"objectgroup"`
+	Groups       []tmxGroup       `xml:"group"`
+}
+
+type tmxGroup struct {
+	OffsetX      float64          `xml:"offsetx,attr"`
+	OffsetY      float64          `xml:"offsety,attr"`
+	ObjectGroups []tmxObjectGroup `xml:"objectgroup"`
+	Groups       []tmxGroup       `xml:"group"`
+}
+
+type tmxObjectGroup struct {
+	Name    string      `xml:"name,attr"`
+	OffsetX float64     `xml:"offsetx,attr"`
+	OffsetY float64     `xml:"offsety,attr"`
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	Rotation   float64       `xml:"rotation,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Properties []tmxProperty `xml:"properties>property"`
+	Polyline   *tmxPoints    `xml:"polyline"`
+	Polygon    *tmxPoints    `xml:"polygon"`
+	Point      *struct{}     `xml:"point"`
+	Ellipse    *struct{}     `xml:"ellipse"`
+}
+
+type tmxPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	// Text is the value of multi-line string properties.
+	Text string `xml:",chardata"`
+}
+
+type tmxTileset struct {
+	FirstGID uint32    `xml:"firstgid,attr"`
+	Source   string    `xml:"source,attr"`
+	Tiles    []tmxTile `xml:"tile"`
+
+	// loaded is true if the external tileset was loaded.
+	loaded bool
+}
+
+type tmxTile struct {
+	ID         uint32        `xml:"id,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+// tiledObject is a map object with the tile class and properties merged in.
+type tiledObject struct {
+	tmxObject
+	class string
+	props map[string]string
+	rect  Rect
+}
+
+// LoadTiledFile loads the camera data from the object layer of a Tiled map (.tmx) file.
+//
+// See LoadTiled(). External tilesets can be anywhere in the file system, e.g. "../tilesets/camera.tsx".
+func LoadTiledFile(path, layer string) (*Level, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.VolumeName(abs) + string(filepath.Separator)
+	name, err := filepath.Rel(root, abs)
+	if err != nil {
+		return nil, err
+	}
+	return LoadTiled(os.DirFS(root), filepath.ToSlash(name), layer)
+}
+
+// LoadTiled loads the camera data from the named object layer of a Tiled map (.tmx) in the file system.
+//
+// External tilesets (.tsx) are resolved relative to the map, and are loaded only for the tile objects
+// of the layer, so a tileset outside of fsys is an error only if the layer uses it. Object classes are mapped to
+// the level data by TiledBounds, TiledZone, TiledRail and TiledPoint. Zone objects support these custom properties:
+//
+//	priority   int     Zone.Priority
+//	lock       string  "x", "y" or "xy", locks the axes of the zone
+//	centerX    float   Zone.CenterX in world units (default is the object center)
+//	centerY    float   Zone.CenterY in world units (default is the object center)
+//	zoom       float   Zone.Zoom
+//	smoothType string  Zone.SmoothType ("None", "Lerp", "SmoothDamp" or "Spring")
+//	blendIn    float   Zone.BlendIn in seconds
+//	blendOut   float   Zone.BlendOut in seconds
+//	bounds     object  a rectangle object used as Zone.Bounds
+//
+// Objects referenced by a bounds property are used only as zone bounds.
+//
+// Tile objects inherit the class and properties of their tile in the tileset.
+//
+// Rotated zone and bounds objects use the bounding box of the rotated rectangle.
+// Rail points are rotated around the object position.
+func LoadTiled(fsys fs.FS, name, layer string) (*Level, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	var m tmxMap
+	if err := xml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("kamera: %s: %w", name, err)
+	}
+	group, offsetX, offsetY, ok := findObjectGroup(m.ObjectGroups, m.Groups, layer, 0, 0)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: %q", ErrLayerNotFound, name, layer)
+	}
+
+	objects := make([]*tiledObject, 0, len(group.Objects))
+	byID := make(map[int]*tiledObject, len(group.Objects))
+	for _, o := range group.Objects {
+		obj := &tiledObject{
+			tmxObject: o,
+			class:     cmp.Or(o.Class, o.Type),
+			props:     map[string]string{},
+			rect:      Rect{o.X + offsetX, o.Y + offsetY, o.Width, o.Height},
+		}
+		if o.GID != 0 {
+			// tile objects are aligned to the bottom-left
+			obj.rect.Y -= o.Height
+			tile, err := m.tile(fsys, name, o.GID&gidMask)
+			if err != nil {
+				return nil, fmt.Errorf("kamera: %s: object %d: %w", name, o.ID, err)
+			}
+			if tile != nil {
+				if obj.class == "" {
+					obj.class = cmp.Or(tile.Class, tile.Type)
+				}
+				setProperties(obj.props, tile.Properties)
+			}
+		}
+		setProperties(obj.props, o.Properties)
+		if o.Rotation != 0 {
+			obj.rect = rotateRect(obj.rect, o.X+offsetX, o.Y+offsetY, o.Rotation)
+		}
+		objects = append(objects, obj)
+		byID[o.ID] = obj
+	}
+
+	level := &Level{Name: strings.TrimSuffix(path.Base(name), path.Ext(name))}
+	if m.Infinite == 0 {
+		level.Bounds = Rect{0, 0, float64(m.Width * m.TileWidth), float64(m.Height * m.TileHeight)}
+	}
+	// objects referenced by a bounds property are not imported on their own
+	zoneBounds := map[int]bool{}
+	for _, obj := range objects {
+		if id, err := strconv.Atoi(obj.props["bounds"]); err == nil && obj.isZone() {
+			zoneBounds[id] = true
+		}
+	}
+	for _, obj := range objects {
+		if zoneBounds[obj.ID] {
+			continue
+		}
+		isPath := obj.Polyline != nil || obj.Polygon != nil
+		isRect := obj.isRect()
+		switch {
+		case strings.EqualFold(obj.class, TiledBounds) && isRect:
+			level.Bounds = obj.rect
+		case obj.isZone():
+			z, err := obj.zone(byID)
+			if err != nil {
+				return nil, fmt.Errorf("kamera: %s: object %d: %w", name, obj.ID, err)
+			}
+			level.Zones = append(level.Zones, z)
+		case (strings.EqualFold(obj.class, TiledRail) || obj.class == "") && isPath:
+			r, err := obj.rail(offsetX, offsetY)
+			if err != nil {
+				return nil, fmt.Errorf("kamera: %s: object %d: %w", name, obj.ID, err)
+			}
+			level.Rails = append(level.Rails, r)
+		case (strings.EqualFold(obj.class, TiledPoint) || obj.class == "") && obj.Point != nil:
+			level.Points = append(level.Points, FocusPoint{obj.Name, obj.rect.X, obj.rect.Y})
+		}
+	}
+	return level, nil
+}
+
+// findObjectGroup returns the named object group and its total offset, searching the groups recursively.
+func findObjectGroup(groups []tmxObjectGroup, nested []tmxGroup, name string, offsetX, offsetY float64) (tmxObjectGroup, float64, float64, bool) {
+	for _, g := range groups {
+		if g.Name == name {
+			return g, offsetX + g.OffsetX, offsetY + g.OffsetY, true
+		}
+	}
+	for _, n := range nested {
+		if g, x, y, ok := findObjectGroup(n.ObjectGroups, n.Groups, name, offsetX+n.OffsetX, offsetY+n.OffsetY); ok {
+			return g, x, y, true
+		}
+	}
+	return tmxObjectGroup{}, 0, 0, false
+}
+
+// tile returns the tileset tile of the global tile ID, or nil if the tile has no data.
+//
+// The external tileset of the tile is loaded relative to the map name on first use.
+func (m *tmxMap) tile(fsys fs.FS, name string, gid uint32) (*tmxTile, error) {
+	var tileset *tmxTileset
+	for i := range m.Tilesets {
+		ts := &m.Tilesets[i]
+		if ts.FirstGID <= gid && (tileset == nil || ts.FirstGID > tileset.FirstGID) {
+			tileset = ts
+		}
+	}
+	if tileset == nil {
+		return nil, nil
+	}
+	if tileset.Source != "" && !tileset.loaded {
+		src := path.Join(path.Dir(name), tileset.Source)
+		data, err := fs.ReadFile(fsys, src)
+		if err != nil {
+			return nil, fmt.Errorf("tileset: %w", err)
+		}
+		var ext tmxTileset
+		if err := xml.Unmarshal(data, &ext); err != nil {
+			return nil, fmt.Errorf("tileset %s: %w", src, err)
+		}
+		tileset.Tiles, tileset.loaded = ext.Tiles, true
+	}
+	for i := range tileset.Tiles {
+		if tileset.Tiles[i].ID == gid-tileset.FirstGID {
+			return &tileset.Tiles[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// isRect returns true if the object is a rectangle.
+func (obj *tiledObject) isRect() bool {
+	return obj.Polyline == nil && obj.Polygon == nil && obj.Point == nil && obj.Ellipse == nil
+}
+
+// isZone returns true if the object is a zone rectangle.
+func (obj *tiledObject) isZone() bool {
+	return (strings.EqualFold(obj.class, TiledZone) || obj.class == "") && obj.isRect()
+}
+
+// zone returns the camera zone of the object.
+func (obj *tiledObject) zone(byID map[int]*tiledObject) (*Zone, error) {
+	z := NewZone(obj.Name, obj.rect)
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(obj.props)) {
+		value := obj.props[key]
+		var err error
+		switch key {
+		case "priority":
+			z.Priority, err = strconv.Atoi(value)
+		case "lock":
+			err = z.setLock(value)
+		case "centerX":
+			z.CenterX, err = strconv.ParseFloat(value, 64)
+		case "centerY":
+			z.CenterY, err = strconv.ParseFloat(value, 64)
+		case "zoom":
+			z.Zoom, err = strconv.ParseFloat(value, 64)
+		case "smoothType":
+			z.OverrideSmoothType = true
+			err = z.SmoothType.UnmarshalText([]byte(value))
+		case "blendIn":
+			z.BlendIn, err = strconv.ParseFloat(value, 64)
+		case "blendOut":
+			z.BlendOut, err = strconv.ParseFloat(value, 64)
+		case "bounds":
+			var id int
+			if id, err = strconv.Atoi(value); err == nil && id != 0 {
+				b, ok := byID[id]
+				if !ok {
+					err = fmt.Errorf("object %d not found", id)
+				} else {
+					z.Bounds = b.rect
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("property %q: %w", key, err))
+		}
+	}
+	return z, errors.Join(errs...)
+}
+
+// rail returns the camera rail of the polyline or polygon object.
+func (obj *tiledObject) rail(offsetX, offsetY float64) (*Rail, error) {
+	r := &Rail{Name: obj.Name, Closed: obj.Polygon != nil}
+	points := obj.Polygon
+	if points == nil {
+		points = obj.Polyline
+	}
+	for _, pair := range strings.Fields(points.Points) {
+		xs, ys, ok := strings.Cut(pair, ",")
+		x, errX := strconv.ParseFloat(xs, 64)
+		y, errY := strconv.ParseFloat(ys, 64)
+		if !ok || errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid point %q", pair)
+		}
+		x, y = rotate(x, y, obj.Rotation)
+		r.Points = append(r.Points, Point{obj.X + offsetX + x, obj.Y + offsetY + y})
+	}
+	return r, nil
+}
+
+// rotateRect returns the bounding box of the rectangle rotated by deg degrees (clockwise) around the origin point.
+func rotateRect(r Rect, originX, originY, deg float64) Rect {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range [4]Point{{r.X, r.Y}, {r.Right(), r.Y}, {r.X, r.Bottom()}, {r.Right(), r.Bottom()}} {
+		x, y := rotate(c.X-originX, c.Y-originY, deg)
+		minX, minY = min(minX, x), min(minY, y)
+		maxX, maxY = max(maxX, x), max(maxY, y)
+	}
+	return Rect{originX + minX, originY + minY, maxX - minX, maxY - minY}
+}
+
+// rotate rotates the vector by deg degrees, clockwise in the y-down Tiled coordinates.
+func rotate(x, y, deg float64) (float64, float64) {
+	if deg == 0 {
+		return x, y
+	}
+	sin, cos := math.Sincos(deg * math.Pi / 180)
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// setProperties sets the property values in the map.
+func setProperties(dst map[string]string, props []tmxProperty) {
+	for _, p := range props {
+		if p.Value == "" && p.Text != "" {
+			dst[p.Name] = p.Text
+		} else {
+			dst[p.Name] = p.Value
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package kamera_test
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+const testTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" width="100" height="50" tilewidth="16" tileheight="16" infinite="0">
+ <tileset firstgid="1" source="camera.tsx"/>
+ <group name="Level" offsetx="10" offsety="0">
+  <objectgroup id="2" name="Camera">
+   <object id="1" class="bounds" x="0" y="0" width="1000" height="500"/>
+   <object id="2" name="arena" class="zone" x="100" y="100" width="200" height="100">
+    <properties>
+     <property name="lock" value="x"/>
+     <property name="zoom" type="float" value="1.5"/>
+     <property name="smoothType" value="SmoothDamp"/>
+     <property name="priority" type="int" value="2"/>
+     <property name="bounds" type="object" value="4"/>
+    </properties>
+   </object>
+   <object id="3" name="track" x="0" y="300">
+    <polyline points="0,0 100,50 200,0"/>
+   </object>
+   <object id="4" class="area" x="50" y="50" width="400" height="300"/>
+   <object id="5" name="vista" gid="1" x="500" y="200" width="64" height="32"/>
+  </objectgroup>
+ </group>
+</map>`
+
+const testTSX = `<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" name="camera" tilewidth="16" tileheight="16" tilecount="1" columns="1">
+ <tile id="0" class="zone">
+  <properties>
+   <property name="lock" value="xy"/>
+   <property name="blendIn" type="float" value="1"/>
+  </properties>
+ </tile>
+</tileset>`
+
+func TestLoadTiled(t *testing.T) {
+	fsys := fstest.MapFS{
+		"maps/level.tmx":  {Data: []byte(testTMX)},
+		"maps/camera.tsx": {Data: []byte(testTSX)},
+	}
+	level, err := kamera.LoadTiled(fsys, "maps/level.tmx", "Camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.Name != "level" || level.Bounds != (kamera.Rect{X: 10, Y: 0, Width: 1000, Height: 500}) {
+		t.Error(level.Name, level.Bounds)
+	}
+	if len(level.Zones) != 2 || len(level.Rails) != 1 {
+		t.Fatal(len(level.Zones), len(level.Rails))
+	}
+	arena := level.Zone("arena")
+	if arena == nil || !arena.LockX || arena.LockY || arena.Zoom != 1.5 || arena.Priority != 2 ||
+		!arena.OverrideSmoothType || arena.SmoothType != kamera.SmoothDamp ||
+		arena.Bounds != (kamera.Rect{X: 60, Y: 50, Width: 400, Height: 300}) {
+		t.Error(arena)
+	}
+	vista := level.Zone("vista")
+	if vista == nil || !vista.LockX || !vista.LockY || vista.BlendIn != 1 || vista.Rect.Y != 168 {
+		t.Error(vista)
+	}
+	track := level.Rail("track")
+	if track == nil || len(track.Points) != 3 || track.Points[1] != (kamera.Point{X: 110, Y: 350}) {
+		t.Error(track)
+	}
+
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	level.Apply(cam)
+	if cam.Rail != track || len(cam.Zones) != 2 {
+		t.Error()
+	}
+
+	if _, err := kamera.LoadTiled(fsys, "maps/level.tmx", "Missing"); !errors.Is(err, kamera.ErrLayerNotFound) {
+		t.Error(err)
+	}
+}
+
+func TestLoadTiledBoundsReference(t *testing.T) {
+	// the referenced rectangle has no class, so it would be a zone on its own
+	tmx := strings.Replace(testTMX, `<object id="4" class="area"`, `<object id="4" name="area"`, 1)
+	tmx = strings.Replace(tmx, `<object id="1" class="bounds" x="0" y="0" width="1000" height="500"/>`, "", 1)
+	fsys := fstest.MapFS{
+		"level.tmx":  {Data: []byte(tmx)},
+		"camera.tsx": {Data: []byte(testTSX)},
+	}
+	level, err := kamera.LoadTiled(fsys, "level.tmx", "Camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(level.Zones) != 2 || level.Zone("area") != nil || level.Bounds != (kamera.Rect{Width: 1600, Height: 800}) {
+		t.Error(len(level.Zones), level.Bounds)
+	}
+	if arena := level.Zone("arena"); arena == nil || arena.Bounds != (kamera.Rect{X: 60, Y: 50, Width: 400, Height: 300}) {
+		t.Error(arena)
+	}
+}
+
+func TestLoadTiledFileTilesetPath(t *testing.T) {
+	dir := t.TempDir()
+	tmx := strings.Replace(testTMX, `source="camera.tsx"`, `source="../tilesets/camera.tsx"`, 1)
+	for name, data := range map[string]string{"maps/level.tmx": tmx, "tilesets/camera.tsx": testTSX} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	level, err := kamera.LoadTiledFile(filepath.Join(dir, "maps", "level.tmx"), "Camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vista := level.Zone("vista"); vista == nil || !vista.LockY || vista.BlendIn != 1 {
+		t.Error(vista)
+	}
+}
+
+func TestLoadTiledMissingTileset(t *testing.T) {
+	// the tileset is loaded only for tile objects
+	noTiles := strings.Replace(testTMX, `<object id="5" name="vista" gid="1" x="500" y="200" width="64" height="32"/>`, "", 1)
+	fsys := fstest.MapFS{
+		"level.tmx":   {Data: []byte(noTiles)},
+		"missing.tmx": {Data: []byte(testTMX)},
+	}
+	if _, err := kamera.LoadTiled(fsys, "level.tmx", "Camera"); err != nil {
+		t.Error(err)
+	}
+	if _, err := kamera.LoadTiled(fsys, "missing.tmx", "Camera"); err == nil || !strings.Contains(err.Error(), "object 5") {
+		t.Error(err)
+	}
+}
+
+func TestLoadTiledRotation(t *testing.T) {
+	const tmx = `<map width="10" height="10" tilewidth="16" tileheight="16">
+ <objectgroup name="Camera">
+  <object id="1" name="z" x="100" y="100" width="20" height="10" rotation="90"/>
+  <object id="2" name="r" x="0" y="0" rotation="90"><polyline points="0,0 10,0"/></object>
+ </objectgroup>
+</map>`
+	level, err := kamera.LoadTiled(fstest.MapFS{"m.tmx": {Data: []byte(tmx)}}, "m.tmx", "Camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if r := level.Zone("z").Rect; !near(r.X, 90) || !near(r.Y, 100) || !near(r.Width, 10) || !near(r.Height, 20) {
+		t.Error(r)
+	}
+	if p := level.Rail("r").Points[1]; !near(p.X, 0) || !near(p.Y, 10) {
+		t.Error(p)
+	}
+}
+
+func TestLoadTiledPropertyErrors(t *testing.T) {
+	const tmx = `<map width="10" height="10" tilewidth="16" tileheight="16">
+ <objectgroup name="Camera">
+  <object id="1" x="0" y="0" width="20" height="10">
+   <properties>
+    <property name="zoom" value="a"/>
+    <property name="blendIn" value="b"/>
+    <property name="priority" value="c"/>
+   </properties>
+  </object>
+ </objectgroup>
+</map>`
+	// the errors are reported in property name order
+	for range 10 {
+		_, err := kamera.LoadTiled(fstest.MapFS{"m.tmx": {Data: []byte(tmx)}}, "m.tmx", "Camera")
+		msg := err.Error()
+		if i, j, k := strings.Index(msg, "blendIn"), strings.Index(msg, "priority"), strings.Index(msg, "zoom"); i < 0 || i > j || j > k {
+			t.Fatal(msg)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package kamera
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+)
+
+// TraceFrame is the camera data recorded for one LookAt() call.
+type TraceFrame struct {
+	// Frame is the index of the recorded frame since the recorder was created or reset.
+	Frame uint64
+	// DeltaTime is the time step of the frame in seconds.
+	DeltaTime float64
+	// TargetX and TargetY are the target passed to LookAt().
+	TargetX, TargetY float64
+	// X and Y are the resulting camera center (including shake offset).
+	X, Y float64
+	// VelocityX and VelocityY are the smoothing velocities.
+	VelocityX, VelocityY float64
+	// Zoom is the zoom factor including shake.
+	Zoom float64
+	// Angle is the camera angle including shake.
+	Angle float64
+	// Trauma is the camera trauma.
+	Trauma float64
+}
+
+// TraceRecorder records TraceFrames into a ring buffer.
+//
+// Set Camera.Recorder to record every LookAt() call. A nil Camera.Recorder records nothing and allocates nothing.
+// The zero value keeps the last second of frames.
+type TraceRecorder struct {
+	// If Paused is true, frames are not recorded.
+	Paused bool
+
+	frames []TraceFrame
+	next   int
+	count  int
+	frame  uint64
+}
+
+// NewTraceRecorder returns a new recorder that keeps the last given seconds of frames.
+func NewTraceRecorder(seconds float64) *TraceRecorder {
+	return &TraceRecorder{frames: make([]TraceFrame, max(1, secondsToFrames(seconds)))}
+}
+
+// record appends the current camera data to the ring buffer.
+func (r *TraceRecorder) record(cam *Camera) {
+	if r.Paused {
+		return
+	}
+	if len(r.frames) == 0 {
+		r.frames = make([]TraceFrame, secondsToFrames(1))
+	}
+	r.frames[r.next] = TraceFrame{
+		Frame:     r.frame,
+		DeltaTime: cam.dt,
+		TargetX:   cam.InputTargetX,
+		TargetY:   cam.InputTargetY,
+		X:         cam.CenterX(),
+		Y:         cam.CenterY(),
+		VelocityX: cam.CurrentVelocityX,
+		VelocityY: cam.CurrentVelocityY,
+		Zoom:      cam.ZoomFactorShake,
+		Angle:     cam.ActualAngle,
+		Trauma:    cam.Trauma,
+	}
+	r.frame++
+	r.next = (r.next + 1) % len(r.frames)
+	r.count = min(r.count+1, len(r.frames))
+}
+
+// Len returns the number of recorded frames in the buffer.
+func (r *TraceRecorder) Len() int {
+	return r.count
+}
+
+// Reset clears the recorded frames.
+func (r *TraceRecorder) Reset() {
+	r.next, r.count, r.frame = 0, 0, 0
+}
+
+// Frames returns a copy of the recorded frames of the last given seconds, oldest first.
+//
+// The duration is measured by summing the DeltaTime of the frames from the newest. 0 seconds returns all recorded frames.
+func (r *TraceRecorder) Frames(seconds float64) []TraceFrame {
+	n := r.count
+	if seconds > 0 {
+		n = 0
+		// the tolerance keeps a frame whose time is only a rounding error past the duration out
+		for elapsed := 0.0; n < r.count && elapsed < seconds-1e-9; n++ {
+			i := r.next - 1 - n
+			if i < 0 {
+				i += len(r.frames)
+			}
+			elapsed += r.frames[i].DeltaTime
+		}
+	}
+	out := make([]TraceFrame, n)
+	start := r.next - n
+	if start < 0 {
+		start += len(r.frames)
+	}
+	for i := range out {
+		out[i] = r.frames[(start+i)%len(r.frames)]
+	}
+	return out
+}
+
+// WriteCSV writes the recorded frames of the last given seconds as CSV with a header row.
+//
+// 0 seconds writes all recorded frames.
+func (r *TraceRecorder) WriteCSV(w io.Writer, seconds float64) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"frame", "dt", "target_x", "target_y", "x", "y", "velocity_x", "velocity_y", "zoom", "angle", "trauma"})
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	for _, fr := range r.Frames(seconds) {
+		cw.Write([]string{
+			strconv.FormatUint(fr.Frame, 10),
+			f(fr.DeltaTime),
+			f(fr.TargetX), f(fr.TargetY),
+			f(fr.X), f(fr.Y),
+			f(fr.VelocityX), f(fr.VelocityY),
+			f(fr.Zoom), f(fr.Angle), f(fr.Trauma),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the recorded frames of the last given seconds as a JSON array.
+//
+// 0 seconds writes all recorded frames.
+func (r *TraceRecorder) WriteJSON(w io.Writer, seconds float64) error {
+	return json.NewEncoder(w).Encode(r.Frames(seconds))
+}
+
+// secondsToFrames returns the number of frames in the duration, rounded to the nearest frame.
+func secondsToFrames(seconds float64) int {
+	return int(math.Round(seconds / deltaTime))
+}
@@ -0,0 +1,89 @@
+package kamera_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestTraceRecorder(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.Recorder = kamera.NewTraceRecorder(1)
+	for i := range 100 {
+		k.LookAt(float64(i), 0)
+	}
+	if k.Recorder.Len() != 60 {
+		t.Error(k.Recorder.Len())
+	}
+	frames := k.Recorder.Frames(0.5)
+	if len(frames) != 30 || frames[29].TargetX != 99 || frames[0].Frame != 70 {
+		t.Error()
+	}
+
+	var buf bytes.Buffer
+	k.Recorder.WriteCSV(&buf, 0.1)
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 7 || !strings.HasPrefix(lines[6], "99,") {
+		t.Error(lines)
+	}
+	buf.Reset()
+	k.Recorder.WriteJSON(&buf, 0)
+	var out []kamera.TraceFrame
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil || len(out) != 60 {
+		t.Error(err)
+	}
+}
+
+func TestTraceRecorderDisabled(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	allocs := testing.AllocsPerRun(100, func() { k.LookAt(1, 2) })
+	if allocs != 0 {
+		t.Error(allocs)
+	}
+}
+
+func TestTraceRecorderRounding(t *testing.T) {
+	// 7 frames summed in floating point is slightly less than 7/60 seconds
+	var seconds float64
+	for range 7 {
+		seconds += 1.0 / 60
+	}
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.Recorder = kamera.NewTraceRecorder(seconds)
+	for i := range 20 {
+		k.LookAt(float64(i), 0)
+	}
+	if k.Recorder.Len() != 7 || len(k.Recorder.Frames(seconds)) != 7 {
+		t.Error(k.Recorder.Len(), len(k.Recorder.Frames(seconds)))
+	}
+}
+
+func TestTraceRecorderZeroValue(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.Recorder = &kamera.TraceRecorder{}
+	for i := range 100 {
+		k.LookAt(float64(i), 0)
+	}
+	if k.Recorder.Len() != 60 {
+		t.Error(k.Recorder.Len())
+	}
+}
+
+func TestTraceRecorderDeltaTime(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	k.Recorder = kamera.NewTraceRecorder(1)
+	for i := range 20 {
+		k.LookAtDt(float64(i), 0, 1.0/30)
+	}
+	frames := k.Recorder.Frames(0.5)
+	if len(frames) != 15 || frames[14].TargetX != 19 {
+		t.Error(len(frames))
+	}
+	var buf bytes.Buffer
+	k.Recorder.WriteCSV(&buf, 0.1)
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 4 {
+		t.Error(lines)
+	}
+}
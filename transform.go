@@ -0,0 +1,169 @@
+package kamera
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Transform is a camera view: center, size, zoom, angle and pivot.
+//
+// Use LerpTransform() to blend two camera views.
+type Transform struct {
+	// X and Y are the view center in world-space.
+	X, Y float64
+	// Width and Height are the view size.
+	Width, Height float64
+	// Zoom is the zoom factor.
+	Zoom float64
+	// Angle is the view angle in radians.
+	Angle float64
+	// AnchorX and AnchorY are the screen-space position of the center in screen fractions. See Camera.AnchorX.
+	AnchorX, AnchorY float64
+	// PivotX and PivotY are the screen-space zoom and rotation pivot in screen fractions. See Camera.PivotMode.
+	PivotX, PivotY float64
+}
+
+// Transform returns the current camera view without shake.
+//
+// The zoom and angle are ZoomFactor and Angle. Use ShakenTransform() for the view on the screen.
+func (cam *Camera) Transform() Transform {
+	px, py := cam.pivotFraction(false)
+	return Transform{
+		X:       cam.CenterX() - cam.TraumaOffsetX,
+		Y:       cam.CenterY() - cam.TraumaOffsetY,
+		Width:   cam.Width,
+		Height:  cam.Height,
+		Zoom:    cam.ZoomFactor,
+		Angle:   cam.Angle,
+		AnchorX: cam.CurrentAnchorX,
+		AnchorY: cam.CurrentAnchorY,
+		PivotX:  px,
+		PivotY:  py,
+	}
+}
+
+// ShakenTransform returns the current camera view including shake, as drawn by ApplyCameraTransform().
+func (cam *Camera) ShakenTransform() Transform {
+	px, py := cam.pivotFraction(true)
+	return Transform{
+		X:       cam.CenterX(),
+		Y:       cam.CenterY(),
+		Width:   cam.Width,
+		Height:  cam.Height,
+		Zoom:    cam.ZoomFactorShake,
+		Angle:   cam.ActualAngle,
+		AnchorX: cam.CurrentAnchorX,
+		AnchorY: cam.CurrentAnchorY,
+		PivotX:  px,
+		PivotY:  py,
+	}
+}
+
+// SetTransform moves the camera to the view without smoothing.
+//
+// The size, zoom factor, angle, anchor and pivot of the camera are set to the view.
+// The pivot mode is PivotAnchor if the pivot is the anchor, otherwise PivotScreen.
+func (cam *Camera) SetTransform(t Transform) {
+	cam.SetAnchor(t.AnchorX, t.AnchorY)
+	cam.CurrentAnchorX, cam.CurrentAnchorY = t.AnchorX, t.AnchorY
+	cam.PivotMode, cam.PivotX, cam.PivotY = PivotAnchor, 0, 0
+	if t.PivotX != t.AnchorX || t.PivotY != t.AnchorY {
+		cam.PivotMode, cam.PivotX, cam.PivotY = PivotScreen, t.PivotX, t.PivotY
+	}
+	if cam.Width != t.Width || cam.Height != t.Height {
+		cam.SetSize(t.Width, t.Height)
+	}
+	cam.ZoomFactor, cam.Angle = t.Zoom, t.Angle
+	cam.SetCenter(t.X, t.Y)
+}
+
+// setView shows the view without the LookAt() pipeline. The shake of the camera is advanced by the time step dt.
+//
+// The anchor and pivot configuration of the camera is not changed, the view pivot is used until the next LookAt().
+func (cam *Camera) setView(t Transform, dt float64) {
+	if cam.Width != t.Width || cam.Height != t.Height {
+		cam.SetSize(t.Width, t.Height)
+	}
+	cam.CurrentAnchorX, cam.CurrentAnchorY = t.AnchorX, t.AnchorY
+	cam.CenterOffsetX = -(cam.Width * t.AnchorX)
+	cam.CenterOffsetY = -(cam.Height * t.AnchorY)
+	cam.viewPivot, cam.viewPivotX, cam.viewPivotY = true, t.PivotX, t.PivotY
+	cam.ZoomFactor, cam.Angle = t.Zoom, t.Angle
+	cam.TempTargetX, cam.TempTargetY = t.X, t.Y
+	cam.PrevTargetX, cam.PrevTargetY = t.X, t.Y
+	cam.CurrentVelocityX, cam.CurrentVelocityY = 0, 0
+	cam.X, cam.Y = t.X, t.Y
+	if dt <= 0 {
+		dt = deltaTime
+	}
+	cam.dt = dt
+	cam.applyShake()
+}
+
+// LerpTransform interpolates two camera views. t is in the range [0-1].
+//
+// The center, size, anchor and pivot are interpolated linearly, the zoom logarithmically (constant perceived zoom speed)
+// and the angle along the shortest arc.
+func LerpTransform(a, b Transform, t float64) Transform {
+	return Transform{
+		X:       lerp(a.X, b.X, t),
+		Y:       lerp(a.Y, b.Y, t),
+		Width:   lerp(a.Width, b.Width, t),
+		Height:  lerp(a.Height, b.Height, t),
+		Zoom:    lerpZoom(a.Zoom, b.Zoom, t),
+		Angle:   lerpAngle(a.Angle, b.Angle, t),
+		AnchorX: lerp(a.AnchorX, b.AnchorX, t),
+		AnchorY: lerp(a.AnchorY, b.AnchorY, t),
+		PivotX:  lerp(a.PivotX, b.PivotX, t),
+		PivotY:  lerp(a.PivotY, b.PivotY, t),
+	}
+}
+
+// LerpCamera interpolates the current views of two cameras. t is in the range [0-1].
+//
+// Call it every frame after both cameras are updated for a continuous cross-fade.
+func LerpCamera(a, b *Camera, t float64) Transform {
+	return LerpTransform(a.Transform(), b.Transform(), t)
+}
+
+// Apply applies the view transformation to the geoM, like Camera.ApplyCameraTransform().
+func (t Transform) Apply(g *ebiten.GeoM) {
+	px, py := t.Width*t.PivotX, t.Height*t.PivotY
+	g.Translate(-t.X+t.Width*t.AnchorX-px, -t.Y+t.Height*t.AnchorY-py)
+	g.Rotate(t.Angle)
+	g.Scale(t.Zoom, t.Zoom)
+	g.Translate(px, py)
+}
+
+// pivotFraction returns the zoom and rotation pivot in screen fractions, relative to the view with or without shake.
+func (cam *Camera) pivotFraction(shake bool) (float64, float64) {
+	if cam.viewPivot {
+		return cam.viewPivotX, cam.viewPivotY
+	}
+	switch cam.PivotMode {
+	case PivotScreen:
+		return cam.PivotX, cam.PivotY
+	case PivotWorld:
+		px, py := cam.screenPivot()
+		if !shake {
+			px, py = px+cam.TraumaOffsetX, py+cam.TraumaOffsetY
+		}
+		return px / cam.Width, py / cam.Height
+	default:
+		return cam.CurrentAnchorX, cam.CurrentAnchorY
+	}
+}
+
+// lerpZoom interpolates zoom factors in log space. Non-positive zoom factors are interpolated linearly.
+func lerpZoom(a, b, t float64) float64 {
+	if a <= 0 || b <= 0 {
+		return lerp(a, b, t)
+	}
+	return math.Exp(lerp(math.Log(a), math.Log(b), t))
+}
+
+// lerpAngle interpolates angles (radians) along the shortest arc.
+func lerpAngle(a, b, t float64) float64 {
+	return a + math.Remainder(b-a, 2*math.Pi)*t
+}
@@ -0,0 +1,95 @@
+package kamera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestLerpTransform(t *testing.T) {
+	a := kamera.Transform{X: 0, Y: 0, Width: 100, Height: 100, Zoom: 1, Angle: math.Pi - 0.1}
+	b := kamera.Transform{X: 100, Y: 50, Width: 200, Height: 100, Zoom: 4, Angle: -math.Pi + 0.1}
+	m := kamera.LerpTransform(a, b, 0.5)
+	if m.X != 50 || m.Y != 25 || m.Width != 150 || math.Abs(m.Zoom-2) > 1e-9 {
+		t.Error(m)
+	}
+	if math.Abs(math.Remainder(m.Angle-math.Pi, 2*math.Pi)) > 1e-9 {
+		t.Error(m.Angle)
+	}
+}
+
+func TestTransformApply(t *testing.T) {
+	cam := kamera.NewCamera(30, 40, 100, 80)
+	cam.ZoomFactor = 2
+	cam.Angle = 0.3
+	cam.LookAt(30, 40)
+
+	var g1, g2 ebiten.GeoM
+	cam.ApplyCameraTransform(&g1)
+	cam.Transform().Apply(&g2)
+	x1, y1 := g1.Apply(12, -7)
+	x2, y2 := g2.Apply(12, -7)
+	if math.Abs(x1-x2) > 1e-9 || math.Abs(y1-y2) > 1e-9 {
+		t.Error(x1, y1, x2, y2)
+	}
+
+	other := kamera.NewCamera(0, 0, 100, 80)
+	other.SetTransform(cam.Transform())
+	if other.CenterX() != 30 || other.ZoomFactor != 2 || other.Angle != 0.3 {
+		t.Error(other.Transform())
+	}
+
+	// a world pivot is kept by Apply and SetTransform
+	cam.PivotMode = kamera.PivotWorld
+	cam.PivotX, cam.PivotY = 50, 20
+	cam.LookAt(30, 40)
+	other.SetTransform(cam.Transform())
+	var g3, g4, g5 ebiten.GeoM
+	cam.ApplyCameraTransform(&g3)
+	cam.Transform().Apply(&g4)
+	other.ApplyCameraTransform(&g5)
+	x3, y3 := g3.Apply(12, -7)
+	x4, y4 := g4.Apply(12, -7)
+	x5, y5 := g5.Apply(12, -7)
+	if math.Abs(x3-x4) > 1e-9 || math.Abs(y3-y4) > 1e-9 || math.Abs(x3-x5) > 1e-9 || math.Abs(y3-y5) > 1e-9 {
+		t.Error(x3, y3, x4, y4, x5, y5)
+	}
+}
+
+func TestTransformShake(t *testing.T) {
+	cam := kamera.NewCamera(30, 40, 100, 80)
+	cam.ShakeEnabled = true
+	cam.SeedShake(3)
+	cam.ZoomFactor = 2
+	cam.PivotMode = kamera.PivotWorld
+	cam.PivotX, cam.PivotY = 50, 20
+	cam.AddTrauma(1)
+	cam.SetShakeFrame(30)
+	cam.LookAt(30, 40)
+
+	v := cam.Transform()
+	if math.Abs(v.X-30) > 1e-9 || math.Abs(v.Y-40) > 1e-9 || v.Zoom != 2 || v.Angle != 0 {
+		t.Error(v)
+	}
+	s := cam.ShakenTransform()
+	if math.Abs(s.X-30) < 1e-6 || s.Zoom != cam.ZoomFactorShake || s.Angle != cam.ActualAngle {
+		t.Error(s)
+	}
+	var g1, g2 ebiten.GeoM
+	cam.ApplyCameraTransform(&g1)
+	s.Apply(&g2)
+	x1, y1 := g1.Apply(12, -7)
+	x2, y2 := g2.Apply(12, -7)
+	if math.Abs(x1-x2) > 1e-9 || math.Abs(y1-y2) > 1e-9 {
+		t.Error(x1, y1, x2, y2)
+	}
+
+	// the shake is not baked into the zoom and angle
+	other := kamera.NewCamera(0, 0, 100, 80)
+	other.SetTransform(v)
+	if other.ZoomFactor != 2 || other.Angle != 0 || math.Abs(other.CenterX()-30) > 1e-9 {
+		t.Error(other.Transform())
+	}
+}
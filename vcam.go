@@ -0,0 +1,166 @@
+package kamera
+
+// Blend is a camera blend curve and duration.
+type Blend struct {
+	// Duration is the blend duration in seconds. 0 means cut.
+	Duration float64
+	// Easing is the blend curve. Default is EaseSmoothstep
+	Easing Easing
+}
+
+// VirtualCamera is a camera setup with its own follow target, offset, zoom and smoothing.
+//
+// A Brain picks the highest priority enabled virtual camera and blends the real camera to it.
+type VirtualCamera struct {
+	// Name is the virtual camera name. It is optional.
+	Name string
+	// Priority of the virtual camera. The Brain uses the enabled camera with the highest priority.
+	Priority int
+	// If Enabled is false, the Brain ignores the virtual camera. Default is true
+	Enabled bool
+	// Follow returns the follow target. If it is nil, the target is (X, Y).
+	Follow func() (x, y float64)
+	// X and Y are the fixed target used if Follow is nil.
+	X, Y float64
+	// OffsetX and OffsetY are added to the follow target in world units.
+	OffsetX, OffsetY float64
+	// Blend is the blend used when the Brain blends to this virtual camera. nil means Brain.DefaultBlend.
+	Blend *Blend
+	// Camera holds the zoom (ZoomFactor), angle, smoothing, dead zone and bounds of the virtual camera.
+	//
+	// The Brain sets its size to the real camera size.
+	Camera *Camera
+
+	started bool
+}
+
+// NewVirtualCamera returns a new enabled virtual camera with default camera settings.
+func NewVirtualCamera(name string, priority int) *VirtualCamera {
+	return &VirtualCamera{
+		Name:     name,
+		Priority: priority,
+		Enabled:  true,
+		Camera:   NewCamera(0, 0, 0, 0),
+	}
+}
+
+// target returns the follow target with the offset.
+func (v *VirtualCamera) target() (float64, float64) {
+	x, y := v.X, v.Y
+	if v.Follow != nil {
+		x, y = v.Follow()
+	}
+	return x + v.OffsetX, y + v.OffsetY
+}
+
+// update moves the virtual camera to the follow target.
+func (v *VirtualCamera) update(w, h, dt float64) {
+	c := v.Camera
+	if c.Width != w || c.Height != h {
+		c.SetSize(w, h)
+	}
+	x, y := v.target()
+	if !v.started {
+		v.started = true
+		c.SetCenter(x, y)
+		return
+	}
+	c.LookAtDt(x, y, dt)
+}
+
+// Brain drives a real camera with the highest priority enabled virtual camera and
+// blends between virtual cameras when the live one changes.
+//
+// Call Update() instead of Camera.LookAt(), or UpdateDt() instead of Camera.LookAtDt().
+type Brain struct {
+	// Cameras are the virtual cameras.
+	Cameras []*VirtualCamera
+	// DefaultBlend is the blend used if the virtual camera has no Blend. Default is 0.5 seconds EaseSmoothstep
+	DefaultBlend Blend
+	// OnCameraChange is called when the live virtual camera changes. It is optional.
+	//
+	// from is nil for the first live camera.
+	OnCameraChange func(from, to *VirtualCamera)
+
+	live     *VirtualCamera
+	blending bool
+	blend    Blend
+	elapsed  float64
+	from     Transform
+	out      Transform
+}
+
+// NewBrain returns a new brain with the default blend.
+func NewBrain(cameras ...*VirtualCamera) *Brain {
+	return &Brain{
+		Cameras:      cameras,
+		DefaultBlend: Blend{Duration: 0.5, Easing: EaseSmoothstep},
+	}
+}
+
+// Live returns the live virtual camera, nil if there is no enabled virtual camera.
+func (b *Brain) Live() *VirtualCamera {
+	return b.live
+}
+
+// IsBlending returns true while a blend between virtual cameras is in progress.
+func (b *Brain) IsBlending() bool {
+	return b.blending
+}
+
+// Update updates the enabled virtual cameras, picks the live one and applies the (blended) view to the camera.
+//
+// The view is written to the camera directly: the follow pipeline of the camera (dead zone, zones, bounds, ...)
+// is not run and its anchor and pivot settings are not changed. The shake of the camera is applied.
+// If there is no enabled virtual camera, the camera is not changed.
+func (b *Brain) Update(cam *Camera) {
+	b.UpdateDt(cam, deltaTime)
+}
+
+// UpdateDt is like Update() with a custom time step dt in seconds. See Camera.LookAtDt().
+func (b *Brain) UpdateDt(cam *Camera, dt float64) {
+	if dt <= 0 {
+		dt = deltaTime
+	}
+	var live *VirtualCamera
+	for _, v := range b.Cameras {
+		if !v.Enabled {
+			v.started = false // snap to the target when enabled again
+			continue
+		}
+		v.update(cam.Width, cam.Height, dt)
+		if live == nil || v.Priority > live.Priority {
+			live = v
+		}
+	}
+	if live == nil {
+		return
+	}
+
+	if live != b.live {
+		from := b.live
+		b.live = live
+		b.blend = b.DefaultBlend
+		if live.Blend != nil {
+			b.blend = *live.Blend
+		}
+		b.blending = from != nil && b.blend.Duration > 0
+		b.elapsed = 0
+		b.from = b.out
+		if b.OnCameraChange != nil {
+			b.OnCameraChange(from, live)
+		}
+	}
+
+	v := live.Camera.Transform()
+	if b.blending {
+		b.elapsed += dt
+		if b.elapsed >= b.blend.Duration {
+			b.blending = false
+		} else {
+			v = LerpTransform(b.from, v, ease(b.blend.Easing, b.elapsed/b.blend.Duration))
+		}
+	}
+	b.out = v
+	cam.setView(v, dt)
+}
@@ -0,0 +1,108 @@
+package kamera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestBrain(t *testing.T) {
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	playerX, playerY := 10.0, 20.0
+	follow := kamera.NewVirtualCamera("follow", 0)
+	follow.Follow = func() (float64, float64) { return playerX, playerY }
+	follow.OffsetY = -5
+	boss := kamera.NewVirtualCamera("boss", 10)
+	boss.X, boss.Y = 200, 0
+	boss.Camera.ZoomFactor = 2
+	boss.Enabled = false
+
+	brain := kamera.NewBrain(follow, boss)
+	var changes int
+	brain.OnCameraChange = func(from, to *kamera.VirtualCamera) { changes++ }
+
+	brain.Update(cam)
+	if brain.Live() != follow || brain.IsBlending() || cam.CenterX() != 10 || cam.CenterY() != 15 {
+		t.Error(cam.CenterX(), cam.CenterY())
+	}
+
+	boss.Enabled = true
+	brain.Update(cam)
+	if brain.Live() != boss || !brain.IsBlending() || changes != 2 {
+		t.Error(brain.Live(), changes)
+	}
+	for range 14 {
+		brain.Update(cam)
+	}
+	if x := cam.CenterX(); x <= 10 || x >= 200 || cam.ZoomFactor <= 1 || cam.ZoomFactor >= 2 {
+		t.Error(x, cam.ZoomFactor)
+	}
+	for range 30 {
+		brain.Update(cam)
+	}
+	if brain.IsBlending() || cam.CenterX() != 200 || cam.ZoomFactor != 2 {
+		t.Error(cam.CenterX(), cam.ZoomFactor)
+	}
+
+	boss.Enabled = false
+	follow.Blend = &kamera.Blend{}
+	brain.Update(cam)
+	if brain.IsBlending() || math.Abs(cam.CenterX()-10) > 1e-9 || cam.ZoomFactor != 1 {
+		t.Error(cam.CenterX(), cam.ZoomFactor)
+	}
+}
+
+func TestBrainKeepsCameraConfig(t *testing.T) {
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	cam.FlipScreen = kamera.DefaultFlipScreenOptions()
+	cam.DeadZoneX = 40
+	cam.Bounds = kamera.Rect{X: 0, Y: 0, Width: 100, Height: 100}
+	cam.PivotMode = kamera.PivotWorld
+	cam.PivotX, cam.PivotY = 5, 5
+	cam.LookAt(10, 10)
+	cam.LookAt(150, 10)
+	cellX, cellY := cam.FlipScreenCell()
+
+	v := kamera.NewVirtualCamera("v", 0)
+	v.X, v.Y = 500, 300
+	v.Camera.PivotMode = kamera.PivotScreen
+	v.Camera.PivotX, v.Camera.PivotY = 0.2, 0.3
+	brain := kamera.NewBrain(v)
+	brain.Update(cam)
+
+	// the view is not clamped by the bounds, dead zone or flip-screen of the real camera
+	if cam.CenterX() != 500 || cam.CenterY() != 300 {
+		t.Error(cam.CenterX(), cam.CenterY())
+	}
+	if x, y := cam.FlipScreenCell(); x != cellX || y != cellY {
+		t.Error(x, y)
+	}
+	if cam.PivotMode != kamera.PivotWorld || cam.PivotX != 5 || cam.PivotY != 5 {
+		t.Error(cam.PivotMode, cam.PivotX, cam.PivotY)
+	}
+	// the view pivot is drawn
+	if p := cam.Transform(); p.PivotX != 0.2 || p.PivotY != 0.3 {
+		t.Error(p)
+	}
+}
+
+func TestBrainUpdateDt(t *testing.T) {
+	cam := kamera.NewCamera(0, 0, 100, 100)
+	cam.ShakeEnabled = true
+	a := kamera.NewVirtualCamera("a", 0)
+	b := kamera.NewVirtualCamera("b", 1)
+	b.X = 100
+	b.Enabled = false
+	brain := kamera.NewBrain(a, b)
+	brain.Update(cam)
+	b.Enabled = true
+	brain.UpdateDt(cam, 0)
+	// the 0.5 second default blend ends after 0.5 seconds of steps
+	for range 15 {
+		brain.UpdateDt(cam, 1.0/30)
+	}
+	if brain.IsBlending() || cam.CenterX() != 100 || cam.ShakeFrame != 32 {
+		t.Error(brain.IsBlending(), cam.CenterX(), cam.ShakeFrame)
+	}
+}
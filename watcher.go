@@ -0,0 +1,151 @@
+package kamera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// PresetWatcher reloads a camera config file when it changes on disk
+// and applies it to the cameras. It is intended for tuning during development.
+//
+// The file is a single preset in JSON (see Preset) or a presets file (see ParsePresets).
+// Call Update() once in the game Update().
+type PresetWatcher struct {
+	// Path is the config file path.
+	Path string
+	// Interval is the minimum time between two file checks. Default is 500ms
+	Interval time.Duration
+	// BlendDuration is the blend duration in seconds used when applying a reloaded config.
+	BlendDuration float64
+	// Cameras are the cameras the config is applied to.
+	Cameras []*Camera
+	// Preset is the name of the preset applied from a presets file. Empty means the first preset.
+	//
+	// All presets of a presets file are registered with RegisterPreset() on reload.
+	Preset string
+	// OnError is called when the file cannot be read or parsed. It is optional.
+	//
+	// The last valid config stays applied.
+	OnError func(err error)
+	// OnReload is called after a reloaded config is applied. It is optional.
+	OnReload func(p *Preset)
+
+	lastCheck time.Time
+	modTime   time.Time
+	size      int64
+	err       error
+}
+
+// NewPresetWatcher returns a new watcher for the config file.
+//
+// The file is loaded on the first Update().
+func NewPresetWatcher(path string, cameras ...*Camera) *PresetWatcher {
+	return &PresetWatcher{
+		Path:     path,
+		Interval: 500 * time.Millisecond,
+		Cameras:  cameras,
+	}
+}
+
+// ParsePreset parses a single preset from JSON.
+//
+// Fields missing in the data keep the default camera settings.
+func ParsePreset(data []byte) (*Preset, error) {
+	p := NewPreset("")
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update checks the file and applies it to the cameras if it has changed since the last check.
+//
+// It returns true if a new config was applied.
+func (w *PresetWatcher) Update() bool {
+	now := time.Now()
+	if !w.lastCheck.IsZero() && now.Sub(w.lastCheck) < w.Interval {
+		return false
+	}
+	w.lastCheck = now
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		w.setErr(err)
+		return false
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return w.Reload() == nil
+}
+
+// Reload reads the file and applies it to the cameras immediately.
+func (w *PresetWatcher) Reload() error {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		w.setErr(err)
+		return err
+	}
+	p, err := w.parse(data)
+	if err != nil {
+		err = fmt.Errorf("kamera: %s: %w", w.Path, err)
+		w.setErr(err)
+		return err
+	}
+	w.err = nil
+	for _, cam := range w.Cameras {
+		cam.SetPreset(p, w.BlendDuration)
+	}
+	if w.OnReload != nil {
+		w.OnReload(p)
+	}
+	return nil
+}
+
+// parse returns the preset to apply from a preset or presets file, and registers the presets of a presets file.
+func (w *PresetWatcher) parse(data []byte) (*Preset, error) {
+	var file struct{ Presets json.RawMessage }
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Presets == nil {
+		return ParsePreset(data)
+	}
+	ps, err := ParsePresets(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) == 0 {
+		return nil, errors.New("no presets")
+	}
+	p := ps[0]
+	if w.Preset != "" {
+		i := slices.IndexFunc(ps, func(p *Preset) bool { return p.Name == w.Preset })
+		if i < 0 {
+			return nil, fmt.Errorf("preset %q not found", w.Preset)
+		}
+		p = ps[i]
+	}
+	for _, p := range ps {
+		RegisterPreset(p)
+	}
+	return p, nil
+}
+
+// Err returns the last read or parse error. It is nil after a successful reload.
+func (w *PresetWatcher) Err() error {
+	return w.err
+}
+
+// setErr sets the error and reports it if it is different from the previous one.
+func (w *PresetWatcher) setErr(err error) {
+	changed := w.err == nil || w.err.Error() != err.Error()
+	w.err = err
+	if changed && w.OnError != nil {
+		w.OnError(err)
+	}
+}
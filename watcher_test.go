@@ -0,0 +1,60 @@
+package kamera_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestPresetWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "camera.json")
+	os.WriteFile(path, []byte(`{"SmoothType":"SmoothDamp","DeadZoneX":25,"ShakeOptions":{"MaxX":3}}`), 0o644)
+
+	k := kamera.NewCamera(0, 0, 100, 100)
+	w := kamera.NewPresetWatcher(path, k)
+	w.Interval = 0
+	errCount := 0
+	w.OnError = func(err error) { errCount++ }
+
+	if !w.Update() || k.SmoothType != kamera.SmoothDamp || k.DeadZoneX != 25 || k.ShakeOptions.MaxX != 3 {
+		t.Fatal(w.Err())
+	}
+	if w.Update() {
+		t.Error("unchanged file reloaded")
+	}
+
+	os.WriteFile(path, []byte(`{"DeadZoneX":`), 0o644)
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second))
+	if w.Update() || w.Err() == nil || errCount != 1 || k.DeadZoneX != 25 {
+		t.Error("parse error not reported")
+	}
+}
+
+func TestPresetWatcherPresetsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	data, err := kamera.MarshalPresets(
+		&kamera.Preset{Name: "watch-a", DeadZoneX: 10},
+		&kamera.Preset{Name: "watch-b", DeadZoneX: 20},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, data, 0o644)
+
+	k := kamera.NewCamera(0, 0, 100, 100)
+	w := kamera.NewPresetWatcher(path, k)
+	w.Preset = "watch-b"
+	if err := w.Reload(); err != nil || k.DeadZoneX != 20 {
+		t.Fatal(err, k.DeadZoneX)
+	}
+	if p, ok := kamera.LookupPreset("watch-a"); !ok || p.DeadZoneX != 10 {
+		t.Error("presets not registered")
+	}
+	w.Preset = "missing"
+	if err := w.Reload(); err == nil || k.DeadZoneX != 20 {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,233 @@
+package kamera
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Zone is a world-space rectangle that overrides the camera follow behavior
+// while the LookAt() target is inside it.
+//
+// Add zones to Camera.Zones. If the target is inside multiple zones, the zone with
+// the highest Priority is active (the first one in Camera.Zones on a tie).
+type Zone struct {
+	// Name is the zone name. It is optional.
+	Name string
+	// Rect is the trigger rectangle of the zone.
+	Rect Rect
+	// Priority of the zone. Higher priority zones override lower ones.
+	Priority int
+	// If LockX is true, the camera center X is locked to CenterX.
+	LockX bool
+	// If LockY is true, the camera center Y is locked to CenterY.
+	LockY bool
+	// CenterX and CenterY are the locked camera center. NewZone() sets them to the Rect center.
+	CenterX, CenterY float64
+	// Zoom is the camera ZoomFactor inside the zone. 0 means no change.
+	//
+	// ZoomFactor changes made while a zone zoom is applied are multiplied with it,
+	// and are kept after the zone fades out.
+	Zoom float64
+	// Bounds replaces the Camera.Bounds inside the zone. Empty rectangle means no change.
+	Bounds Rect
+	// If OverrideSmoothType is true, SmoothType is used instead of the camera smoothing type(s).
+	OverrideSmoothType bool
+	// SmoothType is the smoothing type inside the zone. Used only if OverrideSmoothType is true.
+	SmoothType SmoothType
+	// SmoothOptions replaces the Camera.SmoothOptions inside the zone. nil means no change.
+	SmoothOptions *SmoothOptions
+	// BlendIn is the enter blend duration in seconds. Default is 0.5
+	BlendIn float64
+	// BlendOut is the exit blend duration in seconds. Default is 0.5
+	BlendOut float64
+	// OnEnter is called when the zone becomes the active zone. It is optional.
+	OnEnter func(cam *Camera, z *Zone)
+	// OnExit is called when the zone is no longer the active zone. It is optional.
+	OnExit func(cam *Camera, z *Zone)
+}
+
+// zoneState is the blend state of an active or fading zone.
+type zoneState struct {
+	zone *Zone
+	// progress is the linear blend progress in the range [0-1].
+	progress float64
+}
+
+// NewZone returns a new zone with default blend durations and the locked center in the middle of the rectangle.
+func NewZone(name string, rect Rect) *Zone {
+	x, y := rect.Center()
+	return &Zone{
+		Name:     name,
+		Rect:     rect,
+		CenterX:  x,
+		CenterY:  y,
+		BlendIn:  0.5,
+		BlendOut: 0.5,
+	}
+}
+
+// ActiveZone returns the active zone, or nil if the target is not inside any zone.
+func (cam *Camera) ActiveZone() *Zone {
+	return cam.activeZone
+}
+
+// ZoneWeight returns the current blend weight of the zone in the range [0-1].
+//
+// It is 0 if the zone is neither active nor fading out.
+func (cam *Camera) ZoneWeight(z *Zone) float64 {
+	for _, s := range cam.zoneStates {
+		if s.zone == z {
+			return s.weight()
+		}
+	}
+	return 0
+}
+
+// weight returns the eased blend weight.
+func (s zoneState) weight() float64 {
+	t := s.progress
+	return t * t * (3 - 2*t) // smoothstep
+}
+
+// findZone returns the highest priority zone that contains the point.
+func (cam *Camera) findZone(x, y float64) *Zone {
+	var best *Zone
+	for _, z := range cam.Zones {
+		if z.Rect.Contains(x, y) && (best == nil || z.Priority > best.Priority) {
+			best = z
+		}
+	}
+	return best
+}
+
+// updateZones selects the active zone for the target, advances the zone blends and applies the zone zoom.
+func (cam *Camera) updateZones(targetX, targetY float64) {
+	if len(cam.Zones) == 0 && len(cam.zoneStates) == 0 {
+		cam.activeZone = nil
+		return
+	}
+	if !cam.hasZoneZoom() {
+		cam.zoneBaseZoom, cam.zoneZoomScale = cam.ZoomFactor, 1
+	} else if cam.ZoomFactor != cam.lastZoom && cam.lastZoom > 0 {
+		// the zoom was changed after the last LookAt(), compose it with the zone zoom
+		cam.zoneZoomScale *= cam.ZoomFactor / cam.lastZoom
+	}
+	active := cam.findZone(targetX, targetY)
+	if prev := cam.activeZone; active != prev {
+		cam.activeZone = active
+		if prev != nil && prev.OnExit != nil {
+			prev.OnExit(cam, prev)
+		}
+		if active != nil {
+			found := false
+			for _, s := range cam.zoneStates {
+				found = found || s.zone == active
+			}
+			if !found {
+				cam.zoneStates = append(cam.zoneStates, zoneState{zone: active})
+			}
+			if active.OnEnter != nil {
+				active.OnEnter(cam, active)
+			}
+		}
+	}
+
+	hadZoom := cam.hasZoneZoom()
+
+	// advance the blends and remove finished fade outs
+	states := cam.zoneStates[:0]
+	for _, s := range cam.zoneStates {
+		if s.zone == cam.activeZone {
+			s.progress = blendStep(s.progress, s.zone.BlendIn, 1, cam.dt)
+		} else {
+			s.progress = blendStep(s.progress, s.zone.BlendOut, -1, cam.dt)
+		}
+		if s.progress > 0 || s.zone == cam.activeZone {
+			states = append(states, s)
+		}
+	}
+	clear(cam.zoneStates[len(states):])
+	cam.zoneStates = states
+	// blend in priority order, so the higher priority zones are applied last
+	slices.SortStableFunc(cam.zoneStates, func(a, b zoneState) int {
+		return cmp.Compare(a.zone.Priority, b.zone.Priority)
+	})
+
+	if hadZoom {
+		zoom := cam.zoneBaseZoom
+		for _, s := range cam.zoneStates {
+			if s.zone.Zoom > 0 {
+				zoom = lerp(zoom, s.zone.Zoom, s.weight())
+			}
+		}
+		cam.ZoomFactor = zoom * cam.zoneZoomScale
+	}
+}
+
+// hasZoneZoom returns true if an active or fading zone changes the zoom.
+func (cam *Camera) hasZoneZoom() bool {
+	for _, s := range cam.zoneStates {
+		if s.zone.Zoom > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// blendStep advances the blend progress by the time step dt towards 1 (dir > 0) or 0 (dir < 0).
+func blendStep(progress, duration, dir, dt float64) float64 {
+	if duration <= 0 {
+		return max(0, dir)
+	}
+	return min(max(progress+dir*dt/duration, 0), 1)
+}
+
+// zoneTarget returns the follow target blended with the active and fading zones, clamped to the bounds.
+func (cam *Camera) zoneTarget(targetX, targetY float64) (float64, float64) {
+	x, y := cam.clampToBounds(targetX, targetY, cam.Bounds)
+	for _, s := range cam.zoneStates {
+		z := s.zone
+		zx, zy := targetX, targetY
+		if z.LockX {
+			zx = z.CenterX
+		}
+		if z.LockY {
+			zy = z.CenterY
+		}
+		bounds := cam.Bounds
+		if !z.Bounds.Empty() {
+			bounds = z.Bounds
+		}
+		zx, zy = cam.clampToBounds(zx, zy, bounds)
+		w := s.weight()
+		x, y = lerp(x, zx, w), lerp(y, zy, w)
+	}
+	return x, y
+}
+
+// zoneSmoothOptions returns the smoothing options of the active zone, or nil if it does not change them.
+func (cam *Camera) zoneSmoothOptions() *SmoothOptions {
+	if cam.activeZone == nil {
+		return nil
+	}
+	return cam.activeZone.SmoothOptions
+}
+
+// setLock sets the locked axes from the text "x", "y", "xy" (or "both") and "none" (or empty), case-insensitive.
+func (z *Zone) setLock(s string) error {
+	switch strings.ToLower(s) {
+	case "x":
+		z.LockX, z.LockY = true, false
+	case "y":
+		z.LockX, z.LockY = false, true
+	case "xy", "both":
+		z.LockX, z.LockY = true, true
+	case "", "none":
+		z.LockX, z.LockY = false, false
+	default:
+		return fmt.Errorf("kamera: invalid lock %q", s)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package kamera_test
+
+import (
+	"testing"
+
+	"github.com/setanarut/kamera/v2"
+)
+
+func TestZoneLock(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	arena := kamera.NewZone("arena", kamera.Rect{X: 100, Y: -50, Width: 200, Height: 100})
+	arena.LockX, arena.LockY = true, true
+	arena.BlendIn, arena.BlendOut = 0, 0
+	arena.Zoom = 2
+	var entered, exited int
+	arena.OnEnter = func(*kamera.Camera, *kamera.Zone) { entered++ }
+	arena.OnExit = func(*kamera.Camera, *kamera.Zone) { exited++ }
+	k.Zones = append(k.Zones, arena)
+
+	k.LookAt(120, 10)
+	k.LookAt(150, 20)
+	if k.ActiveZone() != arena || entered != 1 {
+		t.Error(k.ActiveZone(), entered)
+	}
+	if k.CenterX() != 200 || k.CenterY() != 0 || k.ZoomFactor != 2 {
+		t.Error(k.CenterX(), k.CenterY(), k.ZoomFactor)
+	}
+	k.LookAt(500, 20)
+	if k.ActiveZone() != nil || exited != 1 {
+		t.Error(k.ActiveZone(), exited)
+	}
+	if k.CenterX() != 500 || k.ZoomFactor != 1 {
+		t.Error(k.CenterX(), k.ZoomFactor)
+	}
+}
+
+func TestZoneBlendAndPriority(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	low := kamera.NewZone("low", kamera.Rect{X: -1000, Y: -1000, Width: 2000, Height: 2000})
+	high := kamera.NewZone("high", kamera.Rect{X: 0, Y: 0, Width: 100, Height: 100})
+	high.Priority = 1
+	high.LockX = true
+	k.Zones = append(k.Zones, low, high)
+
+	k.LookAt(10, 10)
+	if k.ActiveZone() != high {
+		t.Error(k.ActiveZone())
+	}
+	w := k.ZoneWeight(high)
+	if w <= 0 || w >= 1 || k.CenterX() <= 10 || k.CenterX() >= 50 {
+		t.Error(w, k.CenterX())
+	}
+	for range 30 {
+		k.LookAt(10, 10)
+	}
+	if k.ZoneWeight(high) != 1 || k.CenterX() != 50 {
+		t.Error(k.ZoneWeight(high), k.CenterX())
+	}
+	k.LookAt(200, 10)
+	if k.ActiveZone() != low || k.ZoneWeight(high) <= 0 {
+		t.Error(k.ActiveZone(), k.ZoneWeight(high))
+	}
+}
+
+func TestZonePriorityBlendOrder(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	high := kamera.NewZone("high", kamera.Rect{X: 0, Y: 0, Width: 100, Height: 100})
+	high.Priority = 1
+	high.LockX = true
+	high.BlendIn, high.BlendOut = 0, 10
+	low := kamera.NewZone("low", kamera.Rect{X: 100, Y: 0, Width: 100, Height: 100})
+	low.LockX = true
+	low.BlendIn = 0
+	k.Zones = append(k.Zones, high, low)
+
+	k.LookAt(10, 10)
+	// the fading high priority zone is blended over the active low priority zone
+	k.LookAt(150, 10)
+	if k.ActiveZone() != low || k.CenterX() > 51 {
+		t.Error(k.ActiveZone(), k.CenterX())
+	}
+}
+
+func TestZoneZoomComposesUserZoom(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	z := kamera.NewZone("z", kamera.Rect{X: 0, Y: 0, Width: 100, Height: 100})
+	z.Zoom = 2
+	z.BlendIn, z.BlendOut = 0, 0
+	k.Zones = append(k.Zones, z)
+
+	k.LookAt(10, 10)
+	k.ZoomFactor *= 1.5
+	k.LookAt(10, 10)
+	if k.ZoomFactor != 3 {
+		t.Error(k.ZoomFactor)
+	}
+	k.LookAt(500, 10)
+	if k.ZoomFactor != 1.5 {
+		t.Error(k.ZoomFactor)
+	}
+}
+
+func TestZoneSmoothTypeAxisDisabled(t *testing.T) {
+	k := kamera.NewCamera(0, 0, 100, 100)
+	z := kamera.NewZone("z", kamera.Rect{X: -1000, Y: -1000, Width: 2000, Height: 2000})
+	z.OverrideSmoothType = true
+	z.SmoothType = kamera.Lerp
+	k.Zones = append(k.Zones, z)
+	k.XAxisSmoothingDisabled = true
+
+	k.LookAt(100, 100)
+	if k.CenterX() != 100 || k.CenterY() == 100 {
+		t.Error(k.CenterX(), k.CenterY())
+	}
+}